@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethersphere/beekeeper/pkg/beeclient/cassette"
+	"github.com/spf13/cobra"
+)
+
+const (
+	optionNameRecordCassette = "record-cassette"
+	optionNameReplayCassette = "replay-cassette"
+	optionNameCassetteBody   = "cassette-max-body-size"
+)
+
+// setCassetteFlags sets flags used for recording and replaying Bee API requests
+func setCassetteFlags(cmd *cobra.Command) {
+	cmd.Flags().String(optionNameRecordCassette, "", "file Bee API and debug API requests and responses are recorded to")
+	cmd.Flags().String(optionNameReplayCassette, "", "file recorded responses are replayed from instead of sending requests to the cluster")
+	cmd.Flags().Int64(optionNameCassetteBody, cassette.NewDefaultOptions().MaxBodySize, "size of the largest recorded body, only digest of larger bodies is recorded")
+}
+
+// setupCassette sets cassette that records or replays requests of node clients,
+// returned function closes the recorded cassette file
+func (c *command) setupCassette() (closeCassette func() error, err error) {
+	record := c.globalConfig.GetString(optionNameRecordCassette)
+	replay := c.globalConfig.GetString(optionNameReplayCassette)
+
+	switch {
+	case len(record) > 0 && len(replay) > 0:
+		return nil, errors.New("cassette can not be recorded and replayed at the same time")
+	case len(record) > 0:
+		r, err := cassette.NewRecorder(record, cassette.Options{MaxBodySize: c.globalConfig.GetInt64(optionNameCassetteBody)})
+		if err != nil {
+			return nil, err
+		}
+		c.cassette = r
+		fmt.Printf("recording Bee API requests to %s, run with the same seed to replay them\n", record)
+		return r.Close, nil
+	case len(replay) > 0:
+		interactions, err := cassette.Read(replay)
+		if err != nil {
+			return nil, err
+		}
+		c.cassette = cassette.NewPlayer(interactions)
+		fmt.Printf("replaying %d Bee API requests from %s\n", len(interactions), replay)
+	}
+
+	return func() error { return nil }, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ethersphere/beekeeper/pkg/beeclient/transport"
 	"github.com/ethersphere/beekeeper/pkg/config"
 	"github.com/prometheus/client_golang/prometheus/push"
 	"github.com/spf13/cobra"
@@ -15,6 +16,7 @@ func (c *command) initCheckCmd() (err error) {
 		optionNameClusterName          = "cluster-name"
 		optionNameCreateCluster        = "create-cluster"
 		optionNameChecks               = "checks"
+		optionNameCollectArtifacts     = "collect-artifacts"
 		optionNameMetricsEnabled       = "metrics-enabled"
 		optionNameSeed                 = "seed"
 		optionNameTimeout              = "timeout"
@@ -37,16 +39,46 @@ func (c *command) initCheckCmd() (err error) {
 				return fmt.Errorf("cluster %s not defined", c.globalConfig.GetString(optionNameClusterName))
 			}
 
+			// set tracing of Bee API requests
+			shutdownTracing, err := c.setupTracing()
+			if err != nil {
+				return fmt.Errorf("tracing setup: %w", err)
+			}
+			defer func() {
+				if err := shutdownTracing(cmd.Context()); err != nil {
+					fmt.Printf("tracing shutdown: %v\n", err)
+				}
+			}()
+
+			// set recording or replaying of Bee API requests
+			closeCassette, err := c.setupCassette()
+			if err != nil {
+				return fmt.Errorf("cassette setup: %w", err)
+			}
+			defer func() {
+				if err := closeCassette(); err != nil {
+					fmt.Printf("cassette: %v\n", err)
+				}
+			}()
+
 			// setup cluster
 			cluster, err := c.setupCluster(ctx, c.globalConfig.GetString(optionNameClusterName), c.config, c.globalConfig.GetBool(optionNameCreateCluster))
 			if err != nil {
 				return fmt.Errorf("cluster setup: %w", err)
 			}
 
+			// Bee API requests' metrics are pushed with metrics of every check
+			pusher := push.New(c.globalConfig.GetString(optionNameMetricsPusherAddress), cfgCluster.GetNamespace())
+			if c.globalConfig.GetBool(optionNameMetricsEnabled) {
+				for _, collector := range transport.Collectors() {
+					pusher.Collector(collector)
+				}
+			}
+
 			// set global config
 			checkGlobalConfig := config.CheckGlobalConfig{
 				MetricsEnabled: c.globalConfig.GetBool(optionNameMetricsEnabled),
-				MetricsPusher:  push.New(c.globalConfig.GetString(optionNameMetricsPusherAddress), cfgCluster.GetNamespace()),
+				MetricsPusher:  pusher,
 				Seed:           c.globalConfig.GetInt64(optionNameSeed),
 			}
 
@@ -71,20 +103,36 @@ func (c *command) initCheckCmd() (err error) {
 				}
 
 				// run check
-				if err := check.NewAction().Run(ctx, cluster, o); err != nil {
+				err = check.NewAction().Run(ctx, cluster, o)
+				if c.globalConfig.GetBool(optionNameMetricsEnabled) {
+					if err := pusher.Push(); err != nil {
+						fmt.Printf("check %s: push metrics: %v\n", checkName, err)
+					}
+				}
+				if err != nil {
+					if c.globalConfig.GetBool(optionNameCollectArtifacts) {
+						// check's context may already be expired
+						if err := c.collectArtifacts(cmd.Context(), cluster); err != nil {
+							fmt.Printf("check %s: %v\n", checkName, err)
+						}
+					}
 					return fmt.Errorf("running check %s: %w", checkName, err)
 				}
 			}
 
 			return nil
 		},
-		PreRunE: c.preRunE,
+		PreRunE: c.preRunSwapE,
 	}
 
 	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
 	cmd.Flags().String(optionNameMetricsPusherAddress, "pushgateway.dai.internal", "prometheus metrics pusher address")
 	cmd.Flags().Bool(optionNameCreateCluster, false, "creates cluster before executing checks")
 	cmd.Flags().StringSlice(optionNameChecks, []string{"pingpong"}, "list of checks to execute")
+	cmd.Flags().Bool(optionNameCollectArtifacts, false, "collects diagnostic bundle when a check fails")
+	setArtifactsFlags(cmd)
+	setTracingFlags(cmd)
+	setCassetteFlags(cmd)
 	cmd.Flags().Bool(optionNameMetricsEnabled, false, "enable metrics")
 	cmd.Flags().Int64(optionNameSeed, -1, "seed, -1 for random")
 	cmd.Flags().Duration(optionNameTimeout, 30*time.Minute, "timeout")
@@ -2,10 +2,14 @@ package cmd
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
 
 	"github.com/ethersphere/beekeeper/pkg/bee"
 	"github.com/ethersphere/beekeeper/pkg/config"
+	"github.com/ethersphere/beekeeper/pkg/k8s"
+	"github.com/ethersphere/beekeeper/pkg/keys"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -46,7 +50,11 @@ func (c *command) deleteCluster(ctx context.Context, clusterName string, cfg *co
 					return fmt.Errorf("deleting node %s from the node group %s", nName, ng)
 				}
 
-				if deleteStorage && *ngConfig.PersistenceEnabled {
+				nConfig := ngConfig
+				if v.Nodes[i].Config != nil {
+					nConfig = *v.Nodes[i].Config
+				}
+				if deleteStorage && *nConfig.PersistenceEnabled {
 					pvcName := fmt.Sprintf("data-%s-0", nName)
 					if err := c.k8sClient.PVC.Delete(ctx, pvcName, clusterOptions.Namespace); err != nil {
 						return fmt.Errorf("deleting pvc %s: %w", pvcName, err)
@@ -72,7 +80,11 @@ func (c *command) deleteCluster(ctx context.Context, clusterName string, cfg *co
 						return fmt.Errorf("deleting node %s from the node group %s", nName, ng)
 					}
 
-					if deleteStorage && *ngConfig.PersistenceEnabled {
+					nConfig := ngConfig
+					if v.Nodes[i].Config != nil {
+						nConfig = *v.Nodes[i].Config
+					}
+					if deleteStorage && *nConfig.PersistenceEnabled {
 						pvcName := fmt.Sprintf("data-%s-0", nName)
 						if err := c.k8sClient.PVC.Delete(ctx, pvcName, clusterOptions.Namespace); err != nil {
 							return fmt.Errorf("deleting pvc %s: %w", pvcName, err)
@@ -110,6 +122,12 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 	clusterOptions := clusterConfig.Export()
 	clusterOptions.K8SClient = c.k8sClient
 	clusterOptions.SwapClient = c.swapClient
+	clusterOptions.Cassette = c.cassette
+	if caBundle := clusterConfig.GetTLSCABundle(); len(caBundle) > 0 {
+		if clusterOptions.TLSRootCAs, err = loadCABundle(caBundle); err != nil {
+			return nil, fmt.Errorf("loading TLS CA bundle: %w", err)
+		}
+	}
 
 	cluster = bee.NewCluster(clusterConfig.GetName(), clusterOptions)
 
@@ -143,6 +161,7 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 						return nil, fmt.Errorf("bee profile %s not defined", v.BeeConfig)
 					}
 					bConfig := beeConfig.Export()
+					c.setSimulatedChainConfig(&bConfig)
 					bConfig.Bootnodes = fmt.Sprintf(v.Nodes[i].Bootnodes, clusterConfig.GetNamespace()) // TODO: improve bootnode management, support more than 2 bootnodes
 					bootnodes += bConfig.Bootnodes + " "
 					// set NodeOptions
@@ -161,9 +180,14 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 					if len(v.Nodes[i].SwarmKey) > 0 {
 						nOptions.SwarmKey = v.Nodes[i].SwarmKey
 					}
+					setNodeOverrides(v.Nodes[i], bConfig.Bootnodes, &nOptions)
+					c.setSimulatedChainConfig(nOptions.Config)
+					if err := setNodeKeys(clusterConfig.GetKeysSeed(), nName, nOptions.Config, &nOptions); err != nil {
+						return nil, fmt.Errorf("node %s keys: %w", nName, err)
+					}
 
 					errGroup.Go(func() error {
-						return g.SetupNode(ctx, nName, nOptions, clusterConfig.Funding.Export())
+						return g.SetupNode(ctx, nName, nOptions, clusterConfig.GetFunding(ng))
 					})
 				}
 
@@ -186,6 +210,7 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 					return nil, fmt.Errorf("bee profile %s not defined", v.BeeConfig)
 				}
 				bConfig := beeConfig.Export()
+				c.setSimulatedChainConfig(&bConfig)
 				bConfig.Bootnodes = bootnodes
 				// add node group to the cluster
 				ngOptions := ngConfig.Export()
@@ -207,7 +232,9 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 							nName = v.Nodes[i].Name
 						}
 						// set NodeOptions
-						nOptions := bee.NodeOptions{}
+						nOptions := bee.NodeOptions{
+							Config: &bConfig,
+						}
 						if len(v.Nodes[i].Clef.Key) > 0 {
 							nOptions.ClefKey = v.Nodes[i].Clef.Key
 						}
@@ -220,18 +247,28 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 						if len(v.Nodes[i].SwarmKey) > 0 {
 							nOptions.SwarmKey = v.Nodes[i].SwarmKey
 						}
+						setNodeOverrides(v.Nodes[i], bootnodes, &nOptions)
+						c.setSimulatedChainConfig(nOptions.Config)
+						if err := setNodeKeys(clusterConfig.GetKeysSeed(), nName, nOptions.Config, &nOptions); err != nil {
+							return nil, fmt.Errorf("node %s keys: %w", nName, err)
+						}
 
 						errGroup.Go(func() error {
-							return g.SetupNode(ctx, nName, nOptions, clusterConfig.Funding.Export())
+							return g.SetupNode(ctx, nName, nOptions, clusterConfig.GetFunding(ng))
 						})
 					}
 				} else {
 					for i := 0; i < v.Count; i++ {
 						// set node name
 						nName := fmt.Sprintf("%s-%d", ng, i)
+						// set NodeOptions
+						nOptions := bee.NodeOptions{}
+						if err := setNodeKeys(clusterConfig.GetKeysSeed(), nName, &bConfig, &nOptions); err != nil {
+							return nil, fmt.Errorf("node %s keys: %w", nName, err)
+						}
 
 						errGroup.Go(func() error {
-							return g.SetupNode(ctx, nName, bee.NodeOptions{}, clusterConfig.Funding.Export())
+							return g.SetupNode(ctx, nName, nOptions, clusterConfig.GetFunding(ng))
 						})
 					}
 				}
@@ -270,6 +307,7 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 						return nil, fmt.Errorf("bee profile %s not defined", v.BeeConfig)
 					}
 					bConfig := beeConfig.Export()
+					c.setSimulatedChainConfig(&bConfig)
 					bConfig.Bootnodes = fmt.Sprintf(v.Nodes[i].Bootnodes, clusterConfig.GetNamespace()) // TODO: improve bootnode management, support more than 2 bootnodes
 					bootnodes += bConfig.Bootnodes + " "
 					// set NodeOptions
@@ -288,6 +326,11 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 					if len(v.Nodes[i].SwarmKey) > 0 {
 						nOptions.SwarmKey = v.Nodes[i].SwarmKey
 					}
+					setNodeOverrides(v.Nodes[i], bConfig.Bootnodes, &nOptions)
+					c.setSimulatedChainConfig(nOptions.Config)
+					if err := setNodeKeys(clusterConfig.GetKeysSeed(), nName, nOptions.Config, &nOptions); err != nil {
+						return nil, fmt.Errorf("node %s keys: %w", nName, err)
+					}
 
 					if err := g.AddNode(nName, nOptions); err != nil {
 						return nil, fmt.Errorf("adding node %s: %w", nName, err)
@@ -309,6 +352,7 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 					return nil, fmt.Errorf("bee profile %s not defined", v.BeeConfig)
 				}
 				bConfig := beeConfig.Export()
+				c.setSimulatedChainConfig(&bConfig)
 				bConfig.Bootnodes = bootnodes
 				// add node group to the cluster
 				gOptions := ngConfig.Export()
@@ -329,7 +373,9 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 							nName = v.Nodes[i].Name
 						}
 						// set NodeOptions
-						nOptions := bee.NodeOptions{}
+						nOptions := bee.NodeOptions{
+							Config: &bConfig,
+						}
 						if len(v.Nodes[i].Clef.Key) > 0 {
 							nOptions.ClefKey = v.Nodes[i].Clef.Key
 						}
@@ -342,16 +388,26 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 						if len(v.Nodes[i].SwarmKey) > 0 {
 							nOptions.SwarmKey = v.Nodes[i].SwarmKey
 						}
+						setNodeOverrides(v.Nodes[i], bootnodes, &nOptions)
+						c.setSimulatedChainConfig(nOptions.Config)
+						if err := setNodeKeys(clusterConfig.GetKeysSeed(), nName, nOptions.Config, &nOptions); err != nil {
+							return nil, fmt.Errorf("node %s keys: %w", nName, err)
+						}
 
-						if err := g.AddNode(nName, bee.NodeOptions{}); err != nil {
+						if err := g.AddNode(nName, nOptions); err != nil {
 							return nil, fmt.Errorf("adding node %s: %w", nName, err)
 						}
 					}
 				} else {
 					for i := 0; i < v.Count; i++ {
 						nName := fmt.Sprintf("%s-%d", ng, i)
+						// set NodeOptions
+						nOptions := bee.NodeOptions{}
+						if err := setNodeKeys(clusterConfig.GetKeysSeed(), nName, &bConfig, &nOptions); err != nil {
+							return nil, fmt.Errorf("node %s keys: %w", nName, err)
+						}
 
-						if err := g.AddNode(nName, bee.NodeOptions{}); err != nil {
+						if err := g.AddNode(nName, nOptions); err != nil {
 							return nil, fmt.Errorf("adding node %s: %w", nName, err)
 						}
 					}
@@ -362,3 +418,64 @@ func (c *command) setupCluster(ctx context.Context, clusterName string, cfg *con
 
 	return
 }
+
+// setNodeOverrides sets node's overrides of node group's options and Bee
+// configuration, bootnodes are kept from the node group
+func setNodeOverrides(n config.ClusterNode, bootnodes string, o *bee.NodeOptions) {
+	if n.Config != nil {
+		gOptions := n.Config.Export()
+		o.GroupOptions = &gOptions
+	}
+	if n.BeeConfig != nil {
+		bConfig := n.BeeConfig.Export()
+		bConfig.Bootnodes = bootnodes
+		o.Config = &bConfig
+	}
+}
+
+// setNodeKeys sets node's keys derived from the cluster's keys seed, keys that
+// are already set in the node's configuration are kept
+func setNodeKeys(seed, name string, bConfig *k8s.Config, o *bee.NodeOptions) (err error) {
+	if len(seed) == 0 {
+		return
+	}
+
+	k, err := keys.Generate(name, keys.Options{
+		Seed:         seed,
+		Password:     bConfig.Password,
+		ClefEnabled:  bConfig.ClefSignerEnable && len(o.ClefKey) == 0,
+		ClefPassword: o.ClefPassword,
+		NetworkID:    bConfig.NetworkID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(o.ClefKey) == 0 && len(k.ClefKey) > 0 {
+		o.ClefKey = k.ClefKey
+		o.ClefPassword = k.ClefPassword
+	}
+	if len(o.LibP2PKey) == 0 {
+		o.LibP2PKey = k.LibP2PKey
+	}
+	if len(o.SwarmKey) == 0 {
+		o.SwarmKey = k.SwarmKey
+	}
+
+	return
+}
+
+// loadCABundle returns certificate pool with certificates from the PEM file
+func loadCABundle(path string) (pool *x509.CertPool, err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool = x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+
+	return
+}
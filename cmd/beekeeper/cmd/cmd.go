@@ -1,13 +1,17 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"math/big"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/ethersphere/beekeeper/pkg/beeclient/cassette"
 	"github.com/ethersphere/beekeeper/pkg/config"
 	"github.com/ethersphere/beekeeper/pkg/k8s"
 	"github.com/ethersphere/beekeeper/pkg/swap"
@@ -33,6 +37,8 @@ type command struct {
 	k8sClient *k8s.Client
 	// swap client
 	swapClient swap.Client
+	// cassette records or replays Bee API requests
+	cassette cassette.Transporter
 }
 
 type option func(*command)
@@ -65,10 +71,18 @@ func newCommand(opts ...option) (c *command, err error) {
 		return nil, err
 	}
 
+	if err := c.initCollectCmd(); err != nil {
+		return nil, err
+	}
+
 	if err := c.initCreateCmd(); err != nil {
 		return nil, err
 	}
 
+	if err := c.initDefundCmd(); err != nil {
+		return nil, err
+	}
+
 	if err := c.initDeleteCmd(); err != nil {
 		return nil, err
 	}
@@ -77,20 +91,37 @@ func newCommand(opts ...option) (c *command, err error) {
 		return nil, err
 	}
 
+	if err := c.initKeysCmd(); err != nil {
+		return nil, err
+	}
+
 	if err := c.initPrintCmd(); err != nil {
 		return nil, err
 	}
 
+	if err := c.initRestoreCmd(); err != nil {
+		return nil, err
+	}
+
 	if err := c.initSimulateCmd(); err != nil {
 		return nil, err
 	}
 
+	if err := c.initSnapshotCmd(); err != nil {
+		return nil, err
+	}
+
 	c.initVersionCmd()
 
 	return c, nil
 }
 
 func (c *command) Execute() (err error) {
+	defer func() {
+		if cerr := c.closeSwapClient(); err == nil {
+			err = cerr
+		}
+	}()
 	return c.root.Execute()
 }
 
@@ -175,6 +206,16 @@ func (c *command) preRunE(cmd *cobra.Command, args []string) (err error) {
 	if err := c.setK8S(); err != nil {
 		return err
 	}
+	c.swapClient = &swap.NotSet{}
+	return nil
+}
+
+// preRunSwapE is preRunE of commands that make transactions or read balances,
+// only they set Swap client, as setting it may start the simulated chain
+func (c *command) preRunSwapE(cmd *cobra.Command, args []string) (err error) {
+	if err := c.preRunE(cmd, args); err != nil {
+		return err
+	}
 	// set Swap client
 	if err := c.setSwapClient(); err != nil {
 		return err
@@ -182,6 +223,20 @@ func (c *command) preRunE(cmd *cobra.Command, args []string) (err error) {
 	return nil
 }
 
+// closeSwapClient stops the simulated chain and closes connections of the Swap client
+func (c *command) closeSwapClient() (err error) {
+	switch s := c.swapClient.(type) {
+	case *swap.SimulatedClient:
+		if err := s.Close(); err != nil {
+			return fmt.Errorf("stopping simulated chain: %w", err)
+		}
+	case *swap.SignerClient:
+		s.Close()
+	}
+	c.swapClient = nil
+	return
+}
+
 func (c *command) setK8S() (err error) {
 	if c.globalConfig.GetBool("enable-k8s") {
 		if c.k8sClient, err = k8s.NewClient(&k8s.ClientOptions{
@@ -196,7 +251,15 @@ func (c *command) setK8S() (err error) {
 }
 
 func (c *command) setSwapClient() (err error) {
+	if len(c.globalConfig.GetString("swap-simulated-addr")) > 0 {
+		return c.setSimulatedSwapClient()
+	}
+
 	if len(c.globalConfig.GetString("geth-url")) > 0 {
+		if len(c.globalConfig.GetString("eth-private-key")) > 0 || len(c.globalConfig.GetString("eth-keystore")) > 0 {
+			return c.setSignerSwapClient()
+		}
+
 		gethUrl, err := url.Parse(c.globalConfig.GetString("geth-url"))
 		if err != nil {
 			return fmt.Errorf("parsing Geth URL: %w", err)
@@ -212,3 +275,96 @@ func (c *command) setSwapClient() (err error) {
 
 	return
 }
+
+// setSignerSwapClient sets Swap client that signs transactions locally and
+// sends them to the Geth URL, which can be any standard JSON-RPC node
+func (c *command) setSignerSwapClient() (err error) {
+	o := &swap.SignerClientOptions{
+		BzzTokenAddress:  c.globalConfig.GetString("bzz-token-address"),
+		Confirmations:    c.globalConfig.GetUint64("confirmations"),
+		PrivateKey:       c.globalConfig.GetString("eth-private-key"),
+		Keystore:         c.globalConfig.GetString("eth-keystore"),
+		KeystorePassword: c.globalConfig.GetString("eth-keystore-password"),
+	}
+	if gasPrice := c.globalConfig.GetInt64("gas-price"); gasPrice > 0 {
+		o.GasPrice = big.NewInt(gasPrice)
+	}
+
+	client, err := swap.NewSignerClient(context.Background(), c.globalConfig.GetString("geth-url"), o)
+	if err != nil {
+		return fmt.Errorf("creating signer Swap client: %w", err)
+	}
+	c.swapClient = client
+
+	return
+}
+
+// setSimulatedSwapClient starts in-process simulated chain and sets it as the Swap client
+func (c *command) setSimulatedSwapClient() (err error) {
+	o := &swap.SimulatedClientOptions{
+		ListenAddr:   c.globalConfig.GetString("swap-simulated-addr"),
+		BlockTime:    c.globalConfig.GetDuration("swap-simulated-block-time"),
+		TreasurySeed: c.globalConfig.GetString("swap-simulated-treasury-seed"),
+	}
+
+	if path := c.globalConfig.GetString("swap-simulated-postage-stamp-bin"); len(path) > 0 {
+		bin, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading postage stamp bytecode: %w", err)
+		}
+		o.PostageStampBin = string(bin)
+	}
+
+	if path := c.globalConfig.GetString("swap-simulated-price-oracle-bin"); len(path) > 0 {
+		bin, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading price oracle bytecode: %w", err)
+		}
+		o.PriceOracleBin = string(bin)
+	}
+
+	client, err := swap.NewSimulatedClient(o)
+	if err != nil {
+		return fmt.Errorf("starting simulated chain: %w", err)
+	}
+
+	contracts := client.Contracts()
+	fmt.Printf("simulated chain JSON-RPC endpoint: %s, treasury: %s\n", client.Endpoint(), client.Treasury())
+	fmt.Printf("simulated chain contracts: BZZ token: %s, swap factory: %s, postage stamp: %s, price oracle: %s\n", contracts.BzzToken, contracts.SwapFactory, contracts.PostageStamp, contracts.PriceOracle)
+	if len(contracts.PostageStamp) == 0 || len(contracts.PriceOracle) == 0 {
+		fmt.Println("warning: simulated chain has no postage stamp or price oracle contract, set swap-simulated-postage-stamp-bin and swap-simulated-price-oracle-bin for Bee versions that require them")
+	}
+
+	c.swapClient = client
+
+	return
+}
+
+// simulatedChainEndpoint returns simulated chain's JSON-RPC endpoint as Bee
+// nodes reach it, which differs from the listen address when nodes run in
+// Kubernetes
+func (c *command) simulatedChainEndpoint(s *swap.SimulatedClient) string {
+	if endpoint := c.globalConfig.GetString("swap-simulated-endpoint"); len(endpoint) > 0 {
+		return endpoint
+	}
+	return "http://" + s.Endpoint()
+}
+
+// setSimulatedChainConfig points Bee configuration to the simulated chain's
+// endpoint and contracts, it is noop if the simulated chain is not used
+func (c *command) setSimulatedChainConfig(bConfig *k8s.Config) {
+	s, ok := c.swapClient.(*swap.SimulatedClient)
+	if !ok {
+		return
+	}
+
+	contracts := s.Contracts()
+	bConfig.SwapEndpoint = c.simulatedChainEndpoint(s)
+	bConfig.SwapFactoryAddress = contracts.SwapFactory
+	if len(contracts.PostageStamp) > 0 {
+		bConfig.PostageStampAddress = contracts.PostageStamp
+	}
+	if len(contracts.PriceOracle) > 0 {
+		bConfig.PriceOracleAddress = contracts.PriceOracle
+	}
+}
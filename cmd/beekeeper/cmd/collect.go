@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/artifacts"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/spf13/cobra"
+)
+
+const (
+	optionNameArtifactsDir     = "artifacts-dir"
+	optionNameArtifactsTarball = "artifacts-tarball"
+	optionNameArtifactsTimeout = "artifacts-timeout"
+)
+
+func (c *command) initCollectCmd() (err error) {
+	const (
+		optionNameClusterName = "cluster-name"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "collect",
+		Short: "collects diagnostic bundle of a Bee cluster",
+		Long: `Collects diagnostic bundle of a Bee cluster: pod logs, Kubernetes events, StatefulSet and pod descriptions,
+and topology, peers, balances, settlements, postage batches and reserve state of every node.`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			cluster, err := c.setupCluster(cmd.Context(), c.globalConfig.GetString(optionNameClusterName), c.config, false)
+			if err != nil {
+				return fmt.Errorf("cluster setup: %w", err)
+			}
+
+			return c.collectArtifacts(cmd.Context(), cluster)
+		},
+		PreRunE: c.preRunE,
+	}
+
+	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
+	setArtifactsFlags(cmd)
+
+	c.root.AddCommand(cmd)
+
+	return nil
+}
+
+// setArtifactsFlags sets flags used for collecting diagnostic bundle
+func setArtifactsFlags(cmd *cobra.Command) {
+	cmd.Flags().String(optionNameArtifactsDir, "artifacts", "directory where diagnostic bundle is created")
+	cmd.Flags().Bool(optionNameArtifactsTarball, false, "pack diagnostic bundle into .tar.gz archive")
+	cmd.Flags().Duration(optionNameArtifactsTimeout, 5*time.Minute, "timeout for collecting diagnostic bundle")
+}
+
+// collectArtifacts collects diagnostic bundle of the cluster
+func (c *command) collectArtifacts(ctx context.Context, cluster *bee.Cluster) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, c.globalConfig.GetDuration(optionNameArtifactsTimeout))
+	defer cancel()
+
+	path, err := artifacts.Collect(ctx, cluster, c.k8sClient, artifacts.Options{
+		Dir:     c.globalConfig.GetString(optionNameArtifactsDir),
+		Tarball: c.globalConfig.GetBool(optionNameArtifactsTarball),
+	})
+	if err != nil {
+		return fmt.Errorf("collect artifacts: %w", err)
+	}
+	fmt.Printf("diagnostic bundle collected in %s\n", path)
+
+	return
+}
@@ -25,7 +25,7 @@ func (c *command) initCreateBeeCluster() *cobra.Command {
 
 			return err
 		},
-		PreRunE: c.preRunE,
+		PreRunE: c.preRunSwapE,
 	}
 
 	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
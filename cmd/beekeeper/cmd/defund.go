@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/swap"
+	"github.com/spf13/cobra"
+)
+
+func (c *command) initDefundCmd() (err error) {
+	const (
+		optionNameClusterName = "cluster-name"
+		optionNameTreasury    = "treasury"
+		optionNameTimeout     = "timeout"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "defund",
+		Short: "recovers funds from Bee cluster's nodes",
+		Long: `Defund withdraws chequebook balances of all running cluster's nodes through the debug API and sends remaining BZZ tokens and ETH, except the transaction fee, from their wallets to the treasury address.
+Wallets are swept only for nodes whose keys are known from the configuration or derived from the cluster's keys seed.
+beekeeper defund --cluster-name=default --treasury=0x62cab2b3b55f341f10348720ca18063cdb779ad5`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx, cancel := context.WithTimeout(cmd.Context(), c.globalConfig.GetDuration(optionNameTimeout))
+			defer cancel()
+
+			return c.defundCluster(ctx, c.globalConfig.GetString(optionNameClusterName), c.globalConfig.GetString(optionNameTreasury))
+		},
+		PreRunE: c.preRunSwapE,
+	}
+
+	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
+	cmd.Flags().String(optionNameTreasury, "", "address funds are sent to, if not set it is the account deposits are made from")
+	addDefundSwapFlags(cmd)
+	cmd.Flags().Duration(optionNameTimeout, 15*time.Minute, "timeout")
+
+	c.root.AddCommand(cmd)
+
+	return nil
+}
+
+// addDefundSwapFlags registers flags of the Swap client and sweep
+// transactions read by defundCluster
+func addDefundSwapFlags(cmd *cobra.Command) {
+	cmd.Flags().String("bzz-token-address", "0x6aab14fe9cccd64a502d23842d916eb5321c26e7", "BZZ token address")
+	cmd.Flags().String("eth-account", "0x62cab2b3b55f341f10348720ca18063cdb779ad5", "ETH account address")
+	cmd.Flags().String("geth-url", "http://geth-swap.geth-swap.dai.internal", "Geth node URL")
+	cmd.Flags().Uint64("confirmations", 1, "number of blocks to wait for sweep transactions")
+	cmd.Flags().Int64("gas-price", 0, "gas price for sweep transactions, if not set price suggested by the node is used")
+}
+
+// defundCluster recovers funds from all cluster's nodes to the treasury and
+// prints the report for every node
+func (c *command) defundCluster(ctx context.Context, clusterName, treasury string) (err error) {
+	if len(treasury) == 0 {
+		switch s := c.swapClient.(type) {
+		case *swap.GethClient:
+			treasury = s.Account()
+		case *swap.SignerClient:
+			treasury = s.Address()
+		case *swap.SimulatedClient:
+			treasury = s.Treasury()
+		default:
+			return fmt.Errorf("treasury address not set")
+		}
+	}
+
+	endpoint := c.globalConfig.GetString("geth-url")
+	if s, ok := c.swapClient.(*swap.SimulatedClient); ok {
+		endpoint = c.simulatedChainEndpoint(s)
+	}
+	if len(endpoint) == 0 {
+		return fmt.Errorf("geth URL not set")
+	}
+
+	o := &swap.SignerClientOptions{
+		BzzTokenAddress: c.globalConfig.GetString("bzz-token-address"),
+		Confirmations:   c.globalConfig.GetUint64("confirmations"),
+	}
+	if gasPrice := c.globalConfig.GetInt64("gas-price"); gasPrice > 0 {
+		o.GasPrice = big.NewInt(gasPrice)
+	}
+
+	cluster, err := c.setupCluster(ctx, clusterName, c.config, false)
+	if err != nil {
+		return fmt.Errorf("cluster setup: %w", err)
+	}
+
+	reports, err := cluster.Defund(ctx, bee.DefundOptions{
+		Treasury: treasury,
+		NewSweeper: func(ctx context.Context, privateKey string) (swap.Sweeper, error) {
+			so := *o
+			so.PrivateKey = privateKey
+			s, err := swap.NewSignerClient(ctx, endpoint, &so)
+			if err != nil {
+				return nil, err
+			}
+			return s, nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("defund: %w", err)
+	}
+
+	var failed int
+	fmt.Printf("defund report for cluster %s, treasury: %s\n", clusterName, treasury)
+	for _, r := range reports {
+		fmt.Printf("%s %s: chequebook withdrawn %.4f BZZ %s, wallet swept %.4f BZZ %s, %.6f ETH %s\n", r.Node, r.Address, r.ChequebookWithdraw, r.ChequebookTx, r.BZZ, r.BZZTx, r.ETH, r.ETHTx)
+		if len(r.Skipped) > 0 {
+			fmt.Printf("%s wallet not swept: %s\n", r.Node, r.Skipped)
+		}
+		if r.Err != nil {
+			failed++
+			fmt.Printf("%s defund failed: %v\n", r.Node, r.Err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("defund failed for %d of %d nodes", failed, len(reports))
+	}
+
+	return
+}
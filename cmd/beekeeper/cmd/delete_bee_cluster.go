@@ -11,6 +11,8 @@ func (c *command) initDeleteBeeCluster() *cobra.Command {
 	const (
 		optionNameClusterName = "cluster-name"
 		optionNameWithStorage = "with-storage"
+		optionNameDefund      = "defund"
+		optionNameTreasury    = "treasury"
 		optionNameTimeout     = "timeout"
 	)
 
@@ -22,14 +24,30 @@ func (c *command) initDeleteBeeCluster() *cobra.Command {
 			ctx, cancel := context.WithTimeout(cmd.Context(), c.globalConfig.GetDuration(optionNameTimeout))
 			defer cancel()
 
+			if c.globalConfig.GetBool(optionNameDefund) {
+				// cluster is not deleted if funds are not recovered, so they are not lost
+				if err := c.defundCluster(ctx, c.globalConfig.GetString(optionNameClusterName), c.globalConfig.GetString(optionNameTreasury)); err != nil {
+					return err
+				}
+			}
+
 			return c.deleteCluster(ctx, c.globalConfig.GetString(optionNameClusterName), c.config, c.globalConfig.GetBool(optionNameWithStorage))
 		},
-		PreRunE: c.preRunE,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			// Swap client is needed only to recover funds
+			if defund, _ := cmd.Flags().GetBool(optionNameDefund); defund {
+				return c.preRunSwapE(cmd, args)
+			}
+			return c.preRunE(cmd, args)
+		},
 	}
 
 	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
 	cmd.Flags().Bool(optionNameWithStorage, false, "delete storage")
+	cmd.Flags().Bool(optionNameDefund, false, "recover funds from nodes to the treasury before deleting, see defund command")
+	cmd.Flags().String(optionNameTreasury, "", "address funds are sent to, if not set it is the account deposits are made from")
 	cmd.Flags().Duration(optionNameTimeout, 15*time.Minute, "timeout")
+	addDefundSwapFlags(cmd)
 
 	return cmd
 }
@@ -5,15 +5,23 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ethersphere/beekeeper/pkg/bee"
 	"github.com/spf13/cobra"
 )
 
 func (c *command) initFundCmd() (err error) {
 	const (
 		optionNameAddresses       = "addresses"
+		optionNameClusterName     = "cluster-name"
+		optionNameDryRun          = "dry-run"
 		optionNameEthAccount      = "eth-account"
 		optionNameBzzTokenAddress = "bzz-token-address"
 		optionNameGethURL         = "geth-url"
+		optionNameEthPrivateKey   = "eth-private-key"
+		optionNameEthKeystore     = "eth-keystore"
+		optionNameEthKeystorePass = "eth-keystore-password"
+		optionNameConfirmations   = "confirmations"
+		optionNameGasPrice        = "gas-price"
 		optionNameBzzDeposit      = "bzz-deposit"
 		optionNameEthDeposit      = "eth-deposit"
 		optionNameGBzzDeposit     = "gBzz-deposit"
@@ -25,15 +33,26 @@ func (c *command) initFundCmd() (err error) {
 		Use:   "fund",
 		Short: "funds Ethereum addresses",
 		Long: `Fund makes BZZ tokens and ETH deposits to given Ethereum addresses.
-beekeeper fund --addresses=0xf176839c150e52fe30e5c2b5c648465c6fdfa532,0xebe269e07161c68a942a3a7fce6b4ed66867d6f0`,
-		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			if len(c.globalConfig.GetStringSlice(optionNameAddresses)) < 1 {
-				return fmt.Errorf("bee node Ethereum addresses not provided")
-			}
+beekeeper fund --addresses=0xf176839c150e52fe30e5c2b5c648465c6fdfa532,0xebe269e07161c68a942a3a7fce6b4ed66867d6f0
 
+With cluster name, it tops up on-chain balances of all cluster's nodes to the funding targets from the cluster configuration.
+beekeeper fund --cluster-name=default`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			ctx, cancel := context.WithTimeout(cmd.Context(), c.globalConfig.GetDuration(optionNameTimeout))
 			defer cancel()
 
+			if clusterName := c.globalConfig.GetString(optionNameClusterName); len(clusterName) > 0 {
+				return c.fundCluster(ctx, clusterName, bee.FundingOptions{
+					Eth:  c.globalConfig.GetFloat64(optionNameEthDeposit),
+					Bzz:  c.globalConfig.GetFloat64(optionNameBzzDeposit),
+					GBzz: c.globalConfig.GetFloat64(optionNameGBzzDeposit),
+				}, c.globalConfig.GetBool(optionNameDryRun))
+			}
+
+			if len(c.globalConfig.GetStringSlice(optionNameAddresses)) < 1 {
+				return fmt.Errorf("bee node Ethereum addresses or cluster name not provided")
+			}
+
 			for _, a := range c.globalConfig.GetStringSlice(optionNameAddresses) {
 				// ETH funding
 				ethDeposit := c.globalConfig.GetFloat64(optionNameEthDeposit)
@@ -66,19 +85,92 @@ beekeeper fund --addresses=0xf176839c150e52fe30e5c2b5c648465c6fdfa532,0xebe269e0
 
 			return nil
 		},
-		PreRunE: c.preRunE,
+		PreRunE: c.preRunSwapE,
 	}
 
 	cmd.Flags().StringSlice(optionNameAddresses, nil, "Bee node Ethereum addresses (must start with 0x)")
+	cmd.Flags().String(optionNameClusterName, "", "cluster name, all cluster's nodes are topped up to the funding targets instead of funding addresses")
+	cmd.Flags().Bool(optionNameDryRun, false, "print cluster's funding plan without making deposits")
 	cmd.Flags().String(optionNameBzzTokenAddress, "0x6aab14fe9cccd64a502d23842d916eb5321c26e7", "BZZ token address")
 	cmd.Flags().String(optionNameEthAccount, "0x62cab2b3b55f341f10348720ca18063cdb779ad5", "ETH account address")
 	cmd.Flags().String(optionNameGethURL, "http://geth-swap.geth-swap.dai.internal", "Geth node URL")
-	cmd.Flags().Float64(optionNameBzzDeposit, 0, "BZZ tokens amount to deposit")
-	cmd.Flags().Float64(optionNameGBzzDeposit, 0, "gBZZ tokens amount to deposit")
-	cmd.Flags().Float64(optionNameEthDeposit, 0, "ETH amount to deposit")
+	cmd.Flags().String(optionNameEthPrivateKey, "", "hex encoded private key transactions are signed with locally, instead of using Geth node's ETH account")
+	cmd.Flags().String(optionNameEthKeystore, "", "keystore file transactions are signed with locally, instead of using Geth node's ETH account")
+	cmd.Flags().String(optionNameEthKeystorePass, "", "keystore password")
+	cmd.Flags().Uint64(optionNameConfirmations, 1, "number of blocks to wait for locally signed transactions")
+	cmd.Flags().Int64(optionNameGasPrice, 0, "gas price for locally signed transactions, if not set price suggested by the node is used")
+	cmd.Flags().Float64(optionNameBzzDeposit, 0, "BZZ tokens amount to deposit, with cluster name it overrides BZZ target")
+	cmd.Flags().Float64(optionNameGBzzDeposit, 0, "gBZZ tokens amount to deposit, with cluster name it overrides gBZZ target")
+	cmd.Flags().Float64(optionNameEthDeposit, 0, "ETH amount to deposit, with cluster name it overrides ETH target")
 	cmd.Flags().Duration(optionNameTimeout, 5*time.Minute, "timeout")
 
 	c.root.AddCommand(cmd)
 
 	return nil
 }
+
+// fundCluster tops up balances of all cluster's nodes to the node groups' funding
+// targets, targets set in o override configured ones
+func (c *command) fundCluster(ctx context.Context, clusterName string, o bee.FundingOptions, dryRun bool) (err error) {
+	clusterConfig, ok := c.config.Clusters[clusterName]
+	if !ok {
+		return fmt.Errorf("cluster %s not defined", clusterName)
+	}
+
+	targets := make(map[string]bee.FundingOptions)
+	for ng := range clusterConfig.GetNodeGroups() {
+		t := clusterConfig.GetFunding(ng)
+		if o.Eth > 0 {
+			t.Eth = o.Eth
+		}
+		if o.Bzz > 0 {
+			t.Bzz = o.Bzz
+		}
+		if o.GBzz > 0 {
+			t.GBzz = o.GBzz
+		}
+		targets[ng] = t
+	}
+
+	cluster, err := c.setupCluster(ctx, clusterName, c.config, false)
+	if err != nil {
+		return fmt.Errorf("cluster setup: %w", err)
+	}
+
+	plan, err := cluster.FundingPlan(ctx, targets)
+	if err != nil {
+		return fmt.Errorf("funding plan: %w", err)
+	}
+
+	fmt.Printf("funding plan for cluster %s:\n", clusterName)
+	fmt.Printf("%-16s %-20s %-42s %-5s %16s %16s %16s\n", "NODE GROUP", "NODE", "ADDRESS", "", "BALANCE", "TARGET", "DEPOSIT")
+	for _, d := range plan {
+		fmt.Printf("%-16s %-20s %-42s %-5s %16.4f %16.4f %16.4f\n", d.NodeGroup, d.Node, d.Address, d.Currency, d.Balance, d.Target, d.Amount)
+	}
+
+	if dryRun {
+		return
+	}
+
+	var funded, skipped, failed int
+	fmt.Println("funding receipts:")
+	for _, r := range cluster.Fund(ctx, plan) {
+		switch {
+		case r.Amount <= 0:
+			skipped++
+		case r.Err != nil:
+			failed++
+			fmt.Printf("%s failed to fund with %.4f %s: %v\n", r.Node, r.Amount, r.Currency, r.Err)
+		default:
+			funded++
+			fmt.Printf("%s funded with %.4f %s, transaction: %s\n", r.Node, r.Amount, r.Currency, r.Tx)
+		}
+	}
+	fmt.Printf("deposits: %d funded, %d already at target, %d failed\n", funded, skipped, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d deposits failed", failed, funded+failed)
+	}
+
+	return
+}
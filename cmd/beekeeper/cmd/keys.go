@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s"
+	"github.com/ethersphere/beekeeper/pkg/keys"
+	"github.com/spf13/cobra"
+)
+
+func (c *command) initKeysCmd() (err error) {
+	const (
+		optionNameClusterName = "cluster-name"
+		optionNameExportDir   = "export-dir"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "prints keys derived from the cluster's keys seed",
+		Long: `Prints Ethereum and overlay addresses of keys derived from the cluster's keys seed.
+Keys are derived deterministically from the seed and node name, so nodes keep their addresses across re-creates.
+If export directory is set, encrypted key files are written to <export-dir>/<node>/ directory.
+Nodes with keys set in the configuration are skipped.`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			clusterName := c.globalConfig.GetString(optionNameClusterName)
+			clusterConfig, ok := c.config.Clusters[clusterName]
+			if !ok {
+				return fmt.Errorf("cluster %s not defined", clusterName)
+			}
+
+			seed := clusterConfig.GetKeysSeed()
+			if len(seed) == 0 {
+				return fmt.Errorf("cluster %s keys seed not set", clusterName)
+			}
+			exportDir := c.globalConfig.GetString(optionNameExportDir)
+
+			nodeGroups := clusterConfig.GetNodeGroups()
+			ngs := make([]string, 0, len(nodeGroups))
+			for ng := range nodeGroups {
+				ngs = append(ngs, ng)
+			}
+			sort.Strings(ngs)
+
+			for _, ng := range ngs {
+				v := nodeGroups[ng]
+				beeConfig, ok := c.config.BeeConfigs[v.BeeConfig]
+				if !ok {
+					return fmt.Errorf("bee profile %s not defined", v.BeeConfig)
+				}
+				bConfig := beeConfig.Export()
+
+				var names []string
+				configs := make(map[string]k8s.Config)
+				if len(v.Nodes) > 0 {
+					for i := 0; i < len(v.Nodes); i++ {
+						nName := fmt.Sprintf("%s-%d", ng, i)
+						if len(v.Nodes[i].Name) > 0 {
+							nName = v.Nodes[i].Name
+						}
+						if len(v.Nodes[i].SwarmKey) > 0 || len(v.Nodes[i].Clef.Key) > 0 {
+							fmt.Printf("Node %s. keys set in the configuration\n", nName)
+							continue
+						}
+						names = append(names, nName)
+						configs[nName] = bConfig
+						if v.Nodes[i].BeeConfig != nil {
+							configs[nName] = v.Nodes[i].BeeConfig.Export()
+						}
+					}
+				} else {
+					for i := 0; i < v.Count; i++ {
+						nName := fmt.Sprintf("%s-%d", ng, i)
+						names = append(names, nName)
+						configs[nName] = bConfig
+					}
+				}
+
+				for _, n := range names {
+					bConfig := configs[n]
+					k, err := keys.Generate(n, keys.Options{
+						Seed:        seed,
+						Password:    bConfig.Password,
+						ClefEnabled: bConfig.ClefSignerEnable,
+						NetworkID:   bConfig.NetworkID,
+					})
+					if err != nil {
+						return fmt.Errorf("node %s keys: %w", n, err)
+					}
+
+					fmt.Printf("Node %s. ethereum: %s\n", n, k.Ethereum)
+					fmt.Printf("Node %s. overlay: %s\n", n, k.Overlay)
+
+					if len(exportDir) > 0 {
+						if err := exportKeys(filepath.Join(exportDir, n), k); err != nil {
+							return fmt.Errorf("export node %s keys: %w", n, err)
+						}
+					}
+				}
+			}
+
+			return
+		},
+		PreRunE: c.preRunE,
+	}
+
+	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
+	cmd.Flags().String(optionNameExportDir, "", "directory where encrypted key files are exported")
+
+	c.root.AddCommand(cmd)
+
+	return nil
+}
+
+// exportKeys writes encrypted key files to the directory
+func exportKeys(dir string, k keys.Keys) (err error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		"libp2p.key": k.LibP2PKey,
+		"swarm.key":  k.SwarmKey,
+	}
+	if len(k.ClefKey) > 0 {
+		files["clef.key"] = k.ClefKey
+		files["clef.password"] = k.ClefPassword
+	}
+
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0600); err != nil {
+			return err
+		}
+	}
+
+	return
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+func (c *command) initRestoreCmd() (err error) {
+	const (
+		optionNameClusterName  = "cluster-name"
+		optionNameSnapshotName = "snapshot-name"
+		optionNameTimeout      = "timeout"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "restores Bee cluster from the snapshot",
+		Long: `Restores Bee cluster from the snapshot created by "beekeeper snapshot".
+Nodes are created with data volumes populated from VolumeSnapshots, and their keys and configuration restored from the snapshot.
+Cluster must not exist, delete it with "beekeeper delete bee-cluster --with-storage" before restoring.`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx, cancel := context.WithTimeout(cmd.Context(), c.globalConfig.GetDuration(optionNameTimeout))
+			defer cancel()
+
+			snapshot := c.globalConfig.GetString(optionNameSnapshotName)
+			if len(snapshot) == 0 {
+				return fmt.Errorf("snapshot name not set")
+			}
+
+			clusterName := c.globalConfig.GetString(optionNameClusterName)
+			clusterConfig, ok := c.config.Clusters[clusterName]
+			if !ok {
+				return fmt.Errorf("cluster %s not defined", clusterName)
+			}
+
+			cluster, err := c.setupCluster(ctx, clusterName, c.config, false)
+			if err != nil {
+				return fmt.Errorf("cluster setup: %w", err)
+			}
+
+			// bootnodes are restored first
+			var bootnodeGroups, nodeGroups []string
+			for _, ng := range cluster.NodeGroupsSorted() {
+				if clusterConfig.GetNodeGroups()[ng].Mode == "bootnode" {
+					bootnodeGroups = append(bootnodeGroups, ng)
+				} else {
+					nodeGroups = append(nodeGroups, ng)
+				}
+			}
+
+			for _, ng := range append(bootnodeGroups, nodeGroups...) {
+				g, err := cluster.NodeGroup(ng)
+				if err != nil {
+					return err
+				}
+
+				errGroup := new(errgroup.Group)
+				for _, n := range g.NodesSorted() {
+					n := n
+					errGroup.Go(func() error {
+						return g.RestoreNode(ctx, n, snapshot)
+					})
+				}
+
+				if err := errGroup.Wait(); err != nil {
+					return fmt.Errorf("restore node group %s: %w", ng, err)
+				}
+			}
+
+			fmt.Printf("cluster %s restored from snapshot %s\n", cluster.Name(), snapshot)
+			return
+		},
+		PreRunE: c.preRunE,
+	}
+
+	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
+	cmd.Flags().String(optionNameSnapshotName, "", "snapshot name")
+	cmd.Flags().Duration(optionNameTimeout, 30*time.Minute, "timeout")
+
+	c.root.AddCommand(cmd)
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ethersphere/beekeeper/pkg/beeclient/transport"
 	"github.com/ethersphere/beekeeper/pkg/config"
 	"github.com/prometheus/client_golang/prometheus/push"
 	"github.com/spf13/cobra"
@@ -36,16 +37,46 @@ func (c *command) initSimulateCmd() (err error) {
 				return fmt.Errorf("cluster %s not defined", c.globalConfig.GetString(optionNameClusterName))
 			}
 
+			// set tracing of Bee API requests
+			shutdownTracing, err := c.setupTracing()
+			if err != nil {
+				return fmt.Errorf("tracing setup: %w", err)
+			}
+			defer func() {
+				if err := shutdownTracing(cmd.Context()); err != nil {
+					fmt.Printf("tracing shutdown: %v\n", err)
+				}
+			}()
+
+			// set recording or replaying of Bee API requests
+			closeCassette, err := c.setupCassette()
+			if err != nil {
+				return fmt.Errorf("cassette setup: %w", err)
+			}
+			defer func() {
+				if err := closeCassette(); err != nil {
+					fmt.Printf("cassette: %v\n", err)
+				}
+			}()
+
 			// setup cluster
 			cluster, err := c.setupCluster(ctx, c.globalConfig.GetString(optionNameClusterName), c.config, c.globalConfig.GetBool(optionNameCreateCluster))
 			if err != nil {
 				return fmt.Errorf("cluster setup: %w", err)
 			}
 
+			// Bee API requests' metrics are pushed with metrics of every simulation
+			pusher := push.New(c.globalConfig.GetString(optionNameMetricsPusherAddress), *cfgCluster.Namespace)
+			if c.globalConfig.GetBool(optionNameMetricsEnabled) {
+				for _, collector := range transport.Collectors() {
+					pusher.Collector(collector)
+				}
+			}
+
 			// set global config
 			simulationGlobalConfig := config.SimulationGlobalConfig{
 				MetricsEnabled: c.globalConfig.GetBool(optionNameMetricsEnabled),
-				MetricsPusher:  push.New(c.globalConfig.GetString(optionNameMetricsPusherAddress), *cfgCluster.Namespace),
+				MetricsPusher:  pusher,
 				Seed:           c.globalConfig.GetInt64(optionNameSeed),
 			}
 
@@ -70,14 +101,20 @@ func (c *command) initSimulateCmd() (err error) {
 				}
 
 				// run simulation
-				if err := simulation.NewAction().Run(ctx, cluster, o); err != nil {
+				err = simulation.NewAction().Run(ctx, cluster, o)
+				if c.globalConfig.GetBool(optionNameMetricsEnabled) {
+					if err := pusher.Push(); err != nil {
+						fmt.Printf("simulation %s: push metrics: %v\n", simulationName, err)
+					}
+				}
+				if err != nil {
 					return fmt.Errorf("running simulation %s: %w", simulationName, err)
 				}
 			}
 
 			return nil
 		},
-		PreRunE: c.preRunE,
+		PreRunE: c.preRunSwapE,
 	}
 
 	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
@@ -85,6 +122,8 @@ func (c *command) initSimulateCmd() (err error) {
 	cmd.Flags().Bool(optionNameCreateCluster, false, "creates cluster before executing simulations")
 	cmd.Flags().StringSlice(optionNameSimulations, []string{"upload"}, "list of simulations to execute")
 	cmd.Flags().Bool(optionNameMetricsEnabled, false, "enable metrics")
+	setTracingFlags(cmd)
+	setCassetteFlags(cmd)
 	cmd.Flags().Int64(optionNameSeed, -1, "seed, -1 for random")
 	cmd.Flags().Duration(optionNameTimeout, 30*time.Minute, "timeout")
 
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+func (c *command) initSnapshotCmd() (err error) {
+	const (
+		optionNameClusterName         = "cluster-name"
+		optionNameSnapshotName        = "snapshot-name"
+		optionNameVolumeSnapshotClass = "volume-snapshot-class"
+		optionNameStopNodes           = "stop-nodes"
+		optionNameTimeout             = "timeout"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "creates snapshot of a Bee cluster",
+		Long: `Creates snapshot of a Bee cluster: VolumeSnapshot of every node's data volume, and copies of its keys secret and configuration.
+Snapshot can be used to restore the cluster by running "beekeeper restore".
+Node groups must have persistence enabled and Kubernetes cluster must support VolumeSnapshots.`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx, cancel := context.WithTimeout(cmd.Context(), c.globalConfig.GetDuration(optionNameTimeout))
+			defer cancel()
+
+			snapshot := c.globalConfig.GetString(optionNameSnapshotName)
+			if len(snapshot) == 0 {
+				return fmt.Errorf("snapshot name not set")
+			}
+			volumeSnapshotClass := c.globalConfig.GetString(optionNameVolumeSnapshotClass)
+			stopNodes := c.globalConfig.GetBool(optionNameStopNodes)
+
+			cluster, err := c.setupCluster(ctx, c.globalConfig.GetString(optionNameClusterName), c.config, false)
+			if err != nil {
+				return fmt.Errorf("cluster setup: %w", err)
+			}
+
+			for _, ng := range cluster.NodeGroupsSorted() {
+				g, err := cluster.NodeGroup(ng)
+				if err != nil {
+					return err
+				}
+
+				errGroup := new(errgroup.Group)
+				for _, n := range g.NodesSorted() {
+					n := n
+					errGroup.Go(func() error {
+						// node is stopped to get consistent state of its data volume
+						if stopNodes {
+							if err := g.StopNode(ctx, n); err != nil {
+								return fmt.Errorf("stop node %s: %w", n, err)
+							}
+						}
+
+						if err := g.SnapshotNode(ctx, n, snapshot, volumeSnapshotClass); err != nil {
+							return fmt.Errorf("snapshot node %s: %w", n, err)
+						}
+
+						if stopNodes {
+							if err := g.StartNode(ctx, n); err != nil {
+								return fmt.Errorf("start node %s: %w", n, err)
+							}
+						}
+
+						return nil
+					})
+				}
+
+				if err := errGroup.Wait(); err != nil {
+					return fmt.Errorf("snapshot node group %s: %w", ng, err)
+				}
+			}
+
+			fmt.Printf("snapshot %s of cluster %s created\n", snapshot, cluster.Name())
+			return
+		},
+		PreRunE: c.preRunE,
+	}
+
+	cmd.Flags().String(optionNameClusterName, "default", "cluster name")
+	cmd.Flags().String(optionNameSnapshotName, "", "snapshot name")
+	cmd.Flags().String(optionNameVolumeSnapshotClass, "", "VolumeSnapshotClass used for data volumes, empty for the default class")
+	cmd.Flags().Bool(optionNameStopNodes, true, "stop nodes while their data volumes are snapshotted")
+	cmd.Flags().Duration(optionNameTimeout, 30*time.Minute, "timeout")
+
+	c.root.AddCommand(cmd)
+
+	return nil
+}
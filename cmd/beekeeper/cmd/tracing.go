@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+const (
+	optionNameTracingEndpoint    = "tracing-endpoint"
+	optionNameTracingServiceName = "tracing-service-name"
+)
+
+// setTracingFlags sets flags used for tracing Bee API requests
+func setTracingFlags(cmd *cobra.Command) {
+	cmd.Flags().String(optionNameTracingEndpoint, "", "Jaeger collector endpoint Bee API requests' traces are sent to, e.g. http://localhost:14268/api/traces, tracing is disabled if not set")
+	cmd.Flags().String(optionNameTracingServiceName, "beekeeper", "service name of Bee API requests' traces")
+}
+
+// setupTracing sets global tracer provider that exports spans of Bee API
+// requests to Jaeger collector, returned function flushes and stops exporting
+func (c *command) setupTracing() (shutdown func(context.Context) error, err error) {
+	endpoint := c.globalConfig.GetString(optionNameTracingEndpoint)
+	if len(endpoint) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(endpoint)))
+	if err != nil {
+		return nil, fmt.Errorf("jaeger exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(c.globalConfig.GetString(optionNameTracingServiceName)))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
@@ -3,13 +3,19 @@ module github.com/ethersphere/beekeeper
 go 1.16
 
 require (
+	github.com/ethereum/go-ethereum v1.9.23
 	github.com/ethersphere/bee v0.5.3
 	github.com/ethersphere/bmt v0.1.4
+	github.com/ethersphere/sw3-bindings/v3 v3.0.3
 	github.com/gorilla/websocket v1.4.2
 	github.com/prometheus/client_golang v1.10.0
 	github.com/prometheus/common v0.24.0
 	github.com/spf13/cobra v1.1.3
 	github.com/spf13/viper v1.7.1
+	go.opentelemetry.io/otel v1.0.0
+	go.opentelemetry.io/otel/exporters/jaeger v1.0.0
+	go.opentelemetry.io/otel/sdk v1.0.0
+	go.opentelemetry.io/otel/trace v1.0.0
 	golang.org/x/crypto v0.0.0-20210513164829-c07d793c2f9a
 	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
 	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
@@ -0,0 +1,281 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/k8s"
+)
+
+// Options represents diagnostic bundle options
+type Options struct {
+	Dir     string // directory where bundle is created
+	Tarball bool   // pack bundle into .tar.gz archive and remove the directory
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		Dir:     "artifacts",
+		Tarball: false,
+	}
+}
+
+// Collect collects diagnostic bundle for the cluster and returns its location.
+// Kubernetes objects are collected only if k8sClient is set. Failures to
+// collect individual artifacts do not stop the collection, they are recorded
+// in the errors.txt file of the bundle instead.
+func Collect(ctx context.Context, cluster *bee.Cluster, k8sClient *k8s.Client, o Options) (path string, err error) {
+	b := &bundle{
+		dir: filepath.Join(o.Dir, fmt.Sprintf("%s-%s", cluster.Name(), time.Now().UTC().Format("20060102T150405Z"))),
+	}
+	if err := os.MkdirAll(b.dir, 0o755); err != nil {
+		return "", fmt.Errorf("create bundle directory: %w", err)
+	}
+
+	namespace := cluster.Namespace()
+	if k8sClient != nil {
+		events, err := k8sClient.Event.List(ctx, namespace)
+		b.writeJSON(err, events, "events.json")
+	}
+
+	var wg sync.WaitGroup
+	for name, node := range cluster.Nodes() {
+		wg.Add(1)
+		go func(name string, node *bee.Node) {
+			defer wg.Done()
+
+			if k8sClient != nil {
+				b.collectK8S(ctx, k8sClient, name, namespace)
+			}
+			b.collectNode(ctx, name, node.Client())
+		}(name, node)
+	}
+	wg.Wait()
+
+	if err := b.writeErrors(); err != nil {
+		return "", fmt.Errorf("write errors: %w", err)
+	}
+
+	if !o.Tarball {
+		return b.dir, nil
+	}
+
+	path = b.dir + ".tar.gz"
+	if err := archive(b.dir, path); err != nil {
+		return "", fmt.Errorf("archive bundle: %w", err)
+	}
+	if err := os.RemoveAll(b.dir); err != nil {
+		return "", fmt.Errorf("remove bundle directory: %w", err)
+	}
+
+	return path, nil
+}
+
+// bundle represents diagnostic bundle directory
+type bundle struct {
+	dir string
+
+	mu     sync.Mutex
+	errors []string
+}
+
+// collectK8S collects StatefulSet and Pod descriptions and logs of all Pod's
+// containers, logs of the previous instance are collected for restarted
+// containers as they show why the container crashed
+func (b *bundle) collectK8S(ctx context.Context, k8sClient *k8s.Client, name, namespace string) {
+	s, err := k8sClient.StatefulSet.Get(ctx, name, namespace)
+	b.writeJSON(err, s, "nodes", name, "statefulset.json")
+
+	podName := fmt.Sprintf("%s-0", name)
+	p, err := k8sClient.Pods.Get(ctx, podName, namespace)
+	b.writeJSON(err, p, "nodes", name, "pod.json")
+	if err != nil {
+		return
+	}
+
+	var containers []string
+	for _, c := range p.Spec.InitContainers {
+		containers = append(containers, c.Name)
+	}
+	for _, c := range p.Spec.Containers {
+		containers = append(containers, c.Name)
+	}
+
+	restarted := make(map[string]bool)
+	for _, s := range append(p.Status.InitContainerStatuses, p.Status.ContainerStatuses...) {
+		restarted[s.Name] = s.RestartCount > 0
+	}
+
+	for _, c := range containers {
+		b.collectLogs(ctx, k8sClient, podName, namespace, c, false, "nodes", name, "logs", c+".log")
+		if restarted[c] {
+			b.collectLogs(ctx, k8sClient, podName, namespace, c, true, "nodes", name, "logs", c+".previous.log")
+		}
+	}
+}
+
+// collectLogs writes logs of the Pod's container to the file in the bundle
+func (b *bundle) collectLogs(ctx context.Context, k8sClient *k8s.Client, pod, namespace, container string, previous bool, path ...string) {
+	logs, err := k8sClient.Pods.Logs(ctx, pod, namespace, container, previous)
+	if err != nil {
+		b.addError(err, path...)
+		return
+	}
+	defer logs.Close()
+
+	b.writeStream(logs, path...)
+}
+
+// collectNode collects state of the node from its Bee client
+func (b *bundle) collectNode(ctx context.Context, name string, c *bee.Client) {
+	addresses, err := c.Addresses(ctx)
+	b.writeJSON(err, addresses, "nodes", name, "addresses.json")
+
+	topology, err := c.Topology(ctx)
+	b.writeJSON(err, topology, "nodes", name, "topology.json")
+
+	peers, err := c.Peers(ctx)
+	b.writeJSON(err, peers, "nodes", name, "peers.json")
+
+	balances, err := c.Balances(ctx)
+	b.writeJSON(err, balances, "nodes", name, "balances.json")
+
+	settlements, err := c.Settlements(ctx)
+	b.writeJSON(err, settlements, "nodes", name, "settlements.json")
+
+	batches, err := c.PostageBatches(ctx)
+	b.writeJSON(err, batches, "nodes", name, "postage-batches.json")
+
+	reserveState, err := c.ReserveState(ctx)
+	b.writeJSON(err, reserveState, "nodes", name, "reserve-state.json")
+}
+
+// writeJSON writes v as indented JSON to the file in the bundle, if err is
+// not nil it is recorded instead
+func (b *bundle) writeJSON(err error, v interface{}, path ...string) {
+	if err != nil {
+		b.addError(err, path...)
+		return
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.addError(err, path...)
+		return
+	}
+
+	b.writeStream(bytes.NewReader(data), path...)
+}
+
+// writeStream copies r to the file in the bundle
+func (b *bundle) writeStream(r io.Reader, path ...string) {
+	p := filepath.Join(append([]string{b.dir}, path...)...)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		b.addError(err, path...)
+		return
+	}
+
+	f, err := os.Create(p)
+	if err != nil {
+		b.addError(err, path...)
+		return
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		b.addError(err, path...)
+	}
+}
+
+// addError records error that occurred while collecting given artifact
+func (b *bundle) addError(err error, path ...string) {
+	b.mu.Lock()
+	b.errors = append(b.errors, fmt.Sprintf("%s: %v", filepath.Join(path...), err))
+	b.mu.Unlock()
+}
+
+// writeErrors writes all recorded errors to the errors.txt file
+func (b *bundle) writeErrors() error {
+	if len(b.errors) == 0 {
+		return nil
+	}
+	sort.Strings(b.errors)
+
+	return ioutil.WriteFile(filepath.Join(b.dir, "errors.txt"), []byte(strings.Join(b.errors, "\n")+"\n"), 0o644)
+}
+
+// archive packs directory into gzipped tarball, writers are closed explicitly
+// as their errors mean the tarball is truncated, the tarball is removed then
+func archive(dir, path string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+
+	err = writeTar(tw, dir)
+	if cerr := tw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := gw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+
+	return nil
+}
+
+// writeTar writes the directory and its files to the tar writer
+func writeTar(tw *tar.Writer, dir string) error {
+	base := filepath.Dir(dir)
+	return filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		h, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		if h.Name, err = filepath.Rel(base, p); err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(h); err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		_, err = io.Copy(tw, src)
+		return err
+	})
+}
@@ -0,0 +1,99 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestArchive(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "default-20210101T000000Z")
+	b := &bundle{dir: dir}
+	files := map[string]string{
+		"events.json":                             `{"items":[]}`,
+		"errors.txt":                              "nodes/bee-0/topology.json: timeout\n",
+		"nodes/bee-0/addresses.json":              `{"overlay":"00"}`,
+		"nodes/bee-0/logs/bee.log":                "started\n",
+		"nodes/bee-0/logs/bee.previous.log":       "crashed\n",
+		"nodes/bee-1/logs/init-keys.log":          "",
+		"nodes/bee-1/reserve-state.json":          `{"radius":0}`,
+		"nodes/bee-1/logs/init-keys.previous.log": "done\n",
+	}
+	for p, content := range files {
+		b.writeStream(strings.NewReader(content), filepath.FromSlash(p))
+	}
+	if len(b.errors) > 0 {
+		t.Fatalf("write bundle: %v", b.errors)
+	}
+
+	path := dir + ".tar.gz"
+	if err := archive(dir, path); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+
+	got := make(map[string]string)
+	dirs := make(map[string]bool)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		name := filepath.ToSlash(h.Name)
+		if h.Typeflag == tar.TypeDir {
+			dirs[name] = true
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[name] = string(data)
+	}
+
+	want := make(map[string]string)
+	for p, content := range files {
+		want[filepath.Base(dir)+"/"+p] = content
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got files %v, want %v", got, want)
+	}
+	for _, d := range []string{"", "/nodes", "/nodes/bee-0/logs", "/nodes/bee-1/logs"} {
+		if !dirs[filepath.Base(dir)+d] {
+			t.Fatalf("directory %s not in archive, got %v", filepath.Base(dir)+d, dirs)
+		}
+	}
+}
+
+func TestArchiveMissingDir(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "missing.tar.gz")
+
+	if err := archive(filepath.Join(tmp, "missing"), path); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("truncated archive not removed: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package bee
 
 import (
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"math/rand"
 
 	"github.com/ethersphere/bee/pkg/cac"
+	"github.com/ethersphere/bee/pkg/storage"
 	"github.com/ethersphere/bee/pkg/swarm"
 	bmtlegacy "github.com/ethersphere/bmt/legacy"
 	"golang.org/x/crypto/sha3"
@@ -186,3 +188,39 @@ func AddressOfChunk(chunks ...swarm.Chunk) []swarm.Address {
 		return addrs
 	}
 }
+
+// ChunkCollector is the store of the chunk pipeline that collects addresses
+// of the chunks instead of storing them, so that chunks content is split to
+// are known before it is uploaded
+type ChunkCollector struct {
+	addresses []swarm.Address
+	exists    map[string]struct{}
+}
+
+// compile check whether ChunkCollector implements interface
+var _ storage.Putter = (*ChunkCollector)(nil)
+
+// NewChunkCollector returns new chunk collector
+func NewChunkCollector() *ChunkCollector {
+	return &ChunkCollector{exists: make(map[string]struct{})}
+}
+
+// Put implements storage.Putter, chunks collected before are reported as existing
+func (c *ChunkCollector) Put(_ context.Context, _ storage.ModePut, chs ...swarm.Chunk) ([]bool, error) {
+	exists := make([]bool, len(chs))
+	for i, ch := range chs {
+		key := ch.Address().ByteString()
+		if _, ok := c.exists[key]; ok {
+			exists[i] = true
+			continue
+		}
+		c.addresses = append(c.addresses, ch.Address())
+		c.exists[key] = struct{}{}
+	}
+	return exists, nil
+}
+
+// Addresses returns addresses of the collected chunks in the order they were put
+func (c *ChunkCollector) Addresses() []swarm.Address {
+	return c.addresses
+}
@@ -0,0 +1,42 @@
+package bee
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ethersphere/bee/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/pkg/storage"
+)
+
+func TestChunkCollector(t *testing.T) {
+	ctx := context.Background()
+
+	// content of two equal data chunks is split to one data chunk and the
+	// intermediate chunk referencing it twice
+	content := bytes.Repeat([]byte{1}, 2*MaxChunkSize)
+
+	c := NewChunkCollector()
+	pipe := builder.NewPipelineBuilder(ctx, c, storage.ModePutUpload, false)
+	root, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addresses := c.Addresses()
+	if len(addresses) != 2 {
+		t.Fatalf("got %d addresses, want 2", len(addresses))
+	}
+	if !addresses[len(addresses)-1].Equal(root) {
+		t.Fatalf("got last address %s, want root %s", addresses[len(addresses)-1], root)
+	}
+
+	// chunks are split from content again and reported as existing
+	pipe = builder.NewPipelineBuilder(ctx, c, storage.ModePutUpload, false)
+	if _, err := builder.FeedPipeline(ctx, pipe, bytes.NewReader(content), int64(len(content))); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(c.Addresses()); got != 2 {
+		t.Fatalf("got %d addresses after the same content, want 2", got)
+	}
+}
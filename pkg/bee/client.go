@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -15,19 +16,25 @@ import (
 
 	"github.com/ethersphere/bee/pkg/swarm"
 	"github.com/ethersphere/beekeeper/pkg/beeclient/api"
+	"github.com/ethersphere/beekeeper/pkg/beeclient/cassette"
 	"github.com/ethersphere/beekeeper/pkg/beeclient/debugapi"
+	"github.com/ethersphere/beekeeper/pkg/beeclient/transport"
 )
 
-const retryCount int = 5
+const (
+	// readyAttempts is the number of attempts made while waiting for the node
+	// to become ready
+	readyAttempts = 5
+	// readyBackoff is the wait before the second attempt, it grows linearly
+	// with every attempt, so the last one is made 20 seconds after the first
+	readyBackoff = 2 * time.Second
+)
 
 // Client manages communication with the Bee node
 type Client struct {
 	api   *api.Client
 	debug *debugapi.Client
 	opts  ClientOptions
-
-	// number of times to retry call
-	retry int
 }
 
 // ClientOptions holds optional parameters for the Client.
@@ -36,33 +43,58 @@ type ClientOptions struct {
 	APIInsecureTLS      bool
 	DebugAPIURL         *url.URL
 	DebugAPIInsecureTLS bool
-	Retry               int
+	// Retry is the number of times idempotent request is retried after
+	// connection error or 5xx response, transport's default is used if not set
+	Retry int
+	// Timeout is request timeout used if request's context has no deadline
+	Timeout time.Duration
+	// MaxConnsPerHost limits number of connections to the API and to the debug API
+	MaxConnsPerHost int
+	TLSRootCAs      *x509.CertPool
+	// Cassette records or replays requests of the client, requests are
+	// recorded under the node Name
+	Cassette cassette.Transporter
+	Name     string
 }
 
 // NewClient returns Bee client
 func NewClient(opts ClientOptions) (c *Client) {
 	c = &Client{
-		retry: retryCount,
-		opts:  opts,
+		opts: opts,
 	}
 
 	if opts.APIURL != nil {
-		c.api = api.NewClient(opts.APIURL, &api.ClientOptions{HTTPClient: &http.Client{Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: opts.APIInsecureTLS},
-		}}})
+		var t http.RoundTripper = opts.transport(opts.APIInsecureTLS)
+		if opts.Cassette != nil {
+			t = opts.Cassette.Transport(cassette.Key{Node: opts.Name, API: cassette.API}, t)
+		}
+		c.api = api.NewClient(opts.APIURL, &api.ClientOptions{HTTPClient: &http.Client{Transport: t}})
 	}
 	if opts.DebugAPIURL != nil {
-		c.debug = debugapi.NewClient(opts.DebugAPIURL, &debugapi.ClientOptions{HTTPClient: &http.Client{Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: opts.DebugAPIInsecureTLS},
-		}}})
-	}
-	if opts.Retry > 0 {
-		c.retry = opts.Retry
+		var t http.RoundTripper = opts.transport(opts.DebugAPIInsecureTLS)
+		if opts.Cassette != nil {
+			t = opts.Cassette.Transport(cassette.Key{Node: opts.Name, API: cassette.DebugAPI}, t)
+		}
+		c.debug = debugapi.NewClient(opts.DebugAPIURL, &debugapi.ClientOptions{HTTPClient: &http.Client{Transport: t}})
 	}
 
 	return
 }
 
+// transport returns transport with retries, timeout and connection limits
+// from the options
+func (o ClientOptions) transport(insecureTLS bool) *transport.Transport {
+	to := transport.NewDefaultOptions()
+	if o.Retry > 0 {
+		to.Retries = o.Retry
+	}
+	to.Timeout = o.Timeout
+	to.MaxConnsPerHost = o.MaxConnsPerHost
+	to.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecureTLS, RootCAs: o.TLSRootCAs}
+
+	return transport.New(to)
+}
+
 // Addresses represents node's addresses
 type Addresses struct {
 	Overlay      swarm.Address
@@ -133,14 +165,41 @@ func (c *Client) Balances(ctx context.Context) (resp Balances, err error) {
 	return
 }
 
-// DownloadBytes downloads chunk from the node
-func (c *Client) DownloadBytes(ctx context.Context, a swarm.Address) (data []byte, err error) {
+// DownloadBytes downloads data from the node and returns its size and hash,
+// data is hashed while it is streamed
+func (c *Client) DownloadBytes(ctx context.Context, a swarm.Address) (size int64, hash []byte, err error) {
 	r, err := c.api.Bytes.Download(ctx, a)
 	if err != nil {
-		return nil, fmt.Errorf("download chunk %s: %w", a, err)
+		return 0, nil, fmt.Errorf("download bytes %s: %w", a, err)
 	}
+	defer r.Close()
 
-	return ioutil.ReadAll(r)
+	h := fileHasher()
+	if size, err = io.Copy(h, r); err != nil {
+		return 0, nil, fmt.Errorf("download bytes %s, hashing copy: %w", a, err)
+	}
+
+	return size, h.Sum(nil), nil
+}
+
+// VerifyBytes downloads data from the node and compares it with the expected
+// content while it is streamed
+func (c *Client) VerifyBytes(ctx context.Context, a swarm.Address, expected io.Reader) (err error) {
+	r, err := c.api.Bytes.Download(ctx, a)
+	if err != nil {
+		return fmt.Errorf("download bytes %s: %w", a, err)
+	}
+	defer r.Close()
+
+	offset, err := compareReaders(r, expected)
+	if err != nil {
+		return fmt.Errorf("download bytes %s: %w", a, err)
+	}
+	if offset >= 0 {
+		return fmt.Errorf("bytes %s differ at offset %d: %w", a, offset, ErrFileMismatch)
+	}
+
+	return nil
 }
 
 // DownloadChunk downloads chunk from the node
@@ -169,6 +228,63 @@ func (c *Client) DownloadFile(ctx context.Context, a swarm.Address) (size int64,
 	return size, h.Sum(nil), nil
 }
 
+// VerifyFile downloads the file from the node and verifies its size and hash
+// while it is streamed, content of seeded file is compared with generated one
+// to find offset of the first difference
+func (c *Client) VerifyFile(ctx context.Context, f *File) (err error) {
+	r, err := c.api.Files.Download(ctx, f.Address())
+	if err != nil {
+		return fmt.Errorf("download file %s: %w", f.Address(), err)
+	}
+	defer r.Close()
+
+	if f.seeded {
+		offset, err := compareReaders(r, f.DataReader())
+		if err != nil {
+			return fmt.Errorf("download file %s: %w", f.Address(), err)
+		}
+		if offset >= 0 {
+			return fmt.Errorf("file %s of size %d differs at offset %d: %w", f.Address(), f.Size(), offset, ErrFileMismatch)
+		}
+		return nil
+	}
+
+	h := fileHasher()
+	size, err := io.Copy(h, r)
+	if err != nil {
+		return fmt.Errorf("download file %s, hashing copy: %w", f.Address(), err)
+	}
+	if size != f.Size() || !bytes.Equal(h.Sum(nil), f.Hash()) {
+		return fmt.Errorf("file %s: downloaded size %d, uploaded size %d: %w", f.Address(), size, f.Size(), ErrFileMismatch)
+	}
+
+	return nil
+}
+
+// Health represents node's health and versions, APIVersion and
+// DebugAPIVersion are empty for Bee v0.5.3 as its /health doesn't report them
+type Health struct {
+	Status          string
+	Version         string
+	APIVersion      string
+	DebugAPIVersion string
+}
+
+// Health returns node's health and versions
+func (c *Client) Health(ctx context.Context) (resp Health, err error) {
+	h, err := c.debug.Node.Health(ctx)
+	if err != nil {
+		return Health{}, fmt.Errorf("get health: %w", err)
+	}
+
+	return Health{
+		Status:          h.Status,
+		Version:         h.Version,
+		APIVersion:      h.APIVersion,
+		DebugAPIVersion: h.DebugAPIVersion,
+	}, nil
+}
+
 // HasChunk returns true/false if node has a chunk
 func (c *Client) HasChunk(ctx context.Context, a swarm.Address) (bool, error) {
 	return c.debug.Node.HasChunk(ctx, a)
@@ -189,19 +305,14 @@ func (c *Client) HasChunks(ctx context.Context, a []swarm.Address) (has []bool,
 	return has, count, nil
 }
 
-// Overlay returns node's overlay address
+// Overlay returns node's overlay address, it waits for the node that was just
+// started to become ready
 func (c *Client) Overlay(ctx context.Context) (o swarm.Address, err error) {
 	var a debugapi.Addresses
-	for r := 0; r < c.retry; r++ {
-		time.Sleep(2 * time.Duration(r) * time.Second)
-
+	if err := waitReady(ctx, func() (err error) {
 		a, err = c.debug.Node.Addresses(ctx)
-		if err != nil {
-			continue
-		}
-		break
-	}
-	if err != nil {
+		return err
+	}); err != nil {
 		return swarm.Address{}, fmt.Errorf("get addresses: %w", err)
 	}
 	o = a.Overlay
@@ -223,6 +334,28 @@ func (c *Client) Peers(ctx context.Context) (peers []swarm.Address, err error) {
 	return
 }
 
+// Blocklist returns node's blocklisted peers
+func (c *Client) Blocklist(ctx context.Context) (peers []swarm.Address, err error) {
+	ps, err := c.debug.Node.Blocklist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get blocklist: %w", err)
+	}
+
+	for _, p := range ps.Peers {
+		peers = append(peers, p.Address)
+	}
+
+	return
+}
+
+// DisconnectPeer disconnects the peer
+func (c *Client) DisconnectPeer(ctx context.Context, a swarm.Address) error {
+	if err := c.debug.Node.DisconnectPeer(ctx, a); err != nil {
+		return fmt.Errorf("disconnect peer %s: %w", a, err)
+	}
+	return nil
+}
+
 // PinRootHash pins root hash of given reference.
 func (c *Client) PinRootHash(ctx context.Context, ref swarm.Address) error {
 	return c.api.Pinning.PinRootHash(ctx, ref)
@@ -244,9 +377,78 @@ func (c *Client) GetPins(ctx context.Context) ([]swarm.Address, error) {
 	return c.api.Pinning.GetPins(ctx)
 }
 
+// PinBytes pins all chunks of the data.
+func (c *Client) PinBytes(ctx context.Context, ref swarm.Address) error {
+	return c.api.Pinning.PinBytes(ctx, ref)
+}
+
+// UnpinBytes unpins all chunks of the data.
+func (c *Client) UnpinBytes(ctx context.Context, ref swarm.Address) error {
+	return c.api.Pinning.UnpinBytes(ctx, ref)
+}
+
+// PinFile pins all chunks of the file.
+func (c *Client) PinFile(ctx context.Context, ref swarm.Address) error {
+	return c.api.Pinning.PinFile(ctx, ref)
+}
+
+// UnpinFile unpins all chunks of the file.
+func (c *Client) UnpinFile(ctx context.Context, ref swarm.Address) error {
+	return c.api.Pinning.UnpinFile(ctx, ref)
+}
+
+// PinCollection pins all chunks of the collection.
+func (c *Client) PinCollection(ctx context.Context, ref swarm.Address) error {
+	return c.api.Pinning.PinCollection(ctx, ref)
+}
+
+// UnpinCollection unpins all chunks of the collection.
+func (c *Client) UnpinCollection(ctx context.Context, ref swarm.Address) error {
+	return c.api.Pinning.UnpinCollection(ctx, ref)
+}
+
+// PinChunk increments pin counter of the chunk.
+func (c *Client) PinChunk(ctx context.Context, a swarm.Address) error {
+	return c.api.Pinning.PinChunk(ctx, a)
+}
+
+// UnpinChunk decrements pin counter of the chunk.
+func (c *Client) UnpinChunk(ctx context.Context, a swarm.Address) error {
+	return c.api.Pinning.UnpinChunk(ctx, a)
+}
+
+// PinCounter returns pin counter of the chunk, zero if it is not pinned.
+func (c *Client) PinCounter(ctx context.Context, a swarm.Address) (uint64, error) {
+	p, err := c.api.Pinning.GetPinnedChunk(ctx, a)
+	if err != nil {
+		return 0, fmt.Errorf("pin counter %s: %w", a, err)
+	}
+	return p.PinCounter, nil
+}
+
+// pinnedChunksPage is the number of pinned chunks requested at once
+const pinnedChunksPage = 100
+
+// PinnedChunks returns addresses of all pinned chunks.
+func (c *Client) PinnedChunks(ctx context.Context) (addrs []swarm.Address, err error) {
+	for offset := 0; ; offset += pinnedChunksPage {
+		page, err := c.api.Pinning.GetPinnedChunks(ctx, offset, pinnedChunksPage)
+		if err != nil {
+			return nil, fmt.Errorf("pinned chunks: %w", err)
+		}
+		for _, p := range page {
+			addrs = append(addrs, p.Address)
+		}
+		if len(page) < pinnedChunksPage {
+			return addrs, nil
+		}
+	}
+}
+
 // Ping pings other node
 func (c *Client) Ping(ctx context.Context, node swarm.Address) (rtt string, err error) {
-	r, err := c.debug.PingPong.Ping(ctx, node)
+	// ping doesn't change node's state, so it is retried as idempotent request
+	r, err := c.debug.PingPong.Ping(transport.WithIdempotent(ctx), node)
 	if err != nil {
 		return "", fmt.Errorf("ping node %s: %w", node, err)
 	}
@@ -461,13 +663,19 @@ func (c *Client) CashoutStatus(ctx context.Context, a swarm.Address) (resp Casho
 		}
 	}
 
-	return CashoutStatusResponse{
-		Peer: r.Peer,
-		Cheque: &Cheque{
+	// last cashed cheque is not set before the first cashout
+	var cheque *Cheque
+	if r.Cheque != nil {
+		cheque = &Cheque{
 			Beneficiary: r.Cheque.Beneficiary,
 			Chequebook:  r.Cheque.Chequebook,
 			Payout:      r.Cheque.Payout.Int,
-		},
+		}
+	}
+
+	return CashoutStatusResponse{
+		Peer:            r.Peer,
+		Cheque:          cheque,
 		TransactionHash: r.TransactionHash,
 		Result:          cashoutStatusResult,
 		UncashedAmount:  r.UncashedAmount.Int,
@@ -500,6 +708,36 @@ func (c *Client) ChequebookBalance(ctx context.Context) (resp ChequebookBalanceR
 	}, nil
 }
 
+// ChequebookAddress returns node's chequebook address
+func (c *Client) ChequebookAddress(ctx context.Context) (address string, err error) {
+	r, err := c.debug.Node.ChequebookAddress(ctx)
+	if err != nil {
+		return "", fmt.Errorf("chequebook address: %w", err)
+	}
+
+	return r.ChequebookAddress, nil
+}
+
+// ChequebookDeposit deposits amount from the node's wallet to the chequebook
+func (c *Client) ChequebookDeposit(ctx context.Context, amount *big.Int) (tx string, err error) {
+	r, err := c.debug.Node.ChequebookDeposit(ctx, amount)
+	if err != nil {
+		return "", fmt.Errorf("chequebook deposit: %w", err)
+	}
+
+	return r.TransactionHash, nil
+}
+
+// ChequebookWithdraw withdraws amount from the chequebook to the node's wallet
+func (c *Client) ChequebookWithdraw(ctx context.Context, amount *big.Int) (tx string, err error) {
+	r, err := c.debug.Node.ChequebookWithdraw(ctx, amount)
+	if err != nil {
+		return "", fmt.Errorf("chequebook withdraw: %w", err)
+	}
+
+	return r.TransactionHash, nil
+}
+
 // Topology represents Kademlia topology
 type Topology struct {
 	Overlay        swarm.Address
@@ -519,19 +757,14 @@ type Bin struct {
 	Population        int
 }
 
-// Topology returns Kademlia topology
+// Topology returns Kademlia topology, it waits for the node that was just
+// started to become ready
 func (c *Client) Topology(ctx context.Context) (topology Topology, err error) {
 	var t debugapi.Topology
-	for r := 0; r < c.retry; r++ {
-		time.Sleep(2 * time.Duration(r) * time.Second)
-
+	if err := waitReady(ctx, func() (err error) {
 		t, err = c.debug.Node.Topology(ctx)
-		if err != nil {
-			continue
-		}
-		break
-	}
-	if err != nil {
+		return err
+	}); err != nil {
 		return Topology{}, fmt.Errorf("get topology: %w", err)
 	}
 
@@ -679,3 +912,24 @@ func (c *Client) IsRetrievable(ctx context.Context, ref swarm.Address) (bool, er
 func (c *Client) Reupload(ctx context.Context, ref swarm.Address) error {
 	return c.api.Stewardship.Reupload(ctx, ref)
 }
+
+// waitReady calls f until it succeeds or attempts are exhausted, unlike the
+// transport's retries it retries all errors, as node that is starting may
+// respond with any error until it is ready
+func waitReady(ctx context.Context, f func() error) (err error) {
+	for attempt := 0; attempt < readyAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(time.Duration(attempt) * readyBackoff):
+			case <-ctx.Done():
+				return fmt.Errorf("%w, last error: %v", ctx.Err(), err)
+			}
+		}
+
+		if err = f(); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
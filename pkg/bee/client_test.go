@@ -0,0 +1,62 @@
+package bee
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+)
+
+func TestPinnedChunks(t *testing.T) {
+	for _, count := range []int{0, 1, pinnedChunksPage, 2*pinnedChunksPage + 1} {
+		t.Run(strconv.Itoa(count), func(t *testing.T) {
+			pinned := make([]swarm.Address, count)
+			for i := range pinned {
+				pinned[i] = swarm.MustParseHexAddress(fmt.Sprintf("%064x", i+1))
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/pin/chunks" {
+					http.NotFound(w, r)
+					return
+				}
+				offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+				limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+				var resp struct {
+					Chunks []map[string]interface{} `json:"chunks"`
+				}
+				resp.Chunks = []map[string]interface{}{}
+				for i := offset; i < offset+limit && i < len(pinned); i++ {
+					resp.Chunks = append(resp.Chunks, map[string]interface{}{"address": pinned[i], "pinCounter": 1})
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(resp)
+			}))
+			defer server.Close()
+
+			u, err := url.Parse(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := NewClient(ClientOptions{APIURL: u}).PinnedChunks(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(pinned) {
+				t.Fatalf("got %d pinned chunks, want %d", len(got), len(pinned))
+			}
+			for i := range got {
+				if !got[i].Equal(pinned[i]) {
+					t.Fatalf("chunk %d: got %s, want %s", i, got[i], pinned[i])
+				}
+			}
+		})
+	}
+}
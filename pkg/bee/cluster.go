@@ -2,12 +2,15 @@ package bee
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"math/rand"
 	"net/url"
 	"sort"
+	"time"
 
 	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/beeclient/cassette"
 	"github.com/ethersphere/beekeeper/pkg/k8s"
 	k8sBee "github.com/ethersphere/beekeeper/pkg/k8s/bee"
 	"github.com/ethersphere/beekeeper/pkg/k8s/notset"
@@ -21,6 +24,10 @@ type Cluster struct {
 	apiDomain           string
 	apiInsecureTLS      bool
 	apiScheme           string
+	apiRetries          int           // retries of idempotent requests, transport's default is used if not set
+	apiTimeout          time.Duration // request timeout used if request's context has no deadline
+	apiMaxConnsPerHost  int
+	cassette            cassette.Transporter // records or replays requests of node clients if set
 	debugAPIDomain      string
 	debugAPIInsecureTLS bool
 	debugAPIScheme      string
@@ -29,6 +36,7 @@ type Cluster struct {
 	labels              map[string]string
 	namespace           string
 	disableNamespace    bool                  // do not use namespace for node hostnames
+	tlsRootCAs          *x509.CertPool        // system pool is used if not set
 	nodeGroups          map[string]*NodeGroup // set when groups are added to the cluster
 }
 
@@ -38,6 +46,10 @@ type ClusterOptions struct {
 	APIDomain           string
 	APIInsecureTLS      bool
 	APIScheme           string
+	APIRetries          int
+	APITimeout          time.Duration
+	APIMaxConnsPerHost  int
+	Cassette            cassette.Transporter
 	DebugAPIDomain      string
 	DebugAPIInsecureTLS bool
 	DebugAPIScheme      string
@@ -46,6 +58,7 @@ type ClusterOptions struct {
 	Labels              map[string]string
 	Namespace           string
 	DisableNamespace    bool
+	TLSRootCAs          *x509.CertPool
 }
 
 // NewCluster returns new cluster
@@ -56,6 +69,10 @@ func NewCluster(name string, o ClusterOptions) *Cluster {
 		apiDomain:           o.APIDomain,
 		apiInsecureTLS:      o.APIInsecureTLS,
 		apiScheme:           o.APIScheme,
+		apiRetries:          o.APIRetries,
+		apiTimeout:          o.APITimeout,
+		apiMaxConnsPerHost:  o.APIMaxConnsPerHost,
+		cassette:            o.Cassette,
 		debugAPIDomain:      o.DebugAPIDomain,
 		debugAPIInsecureTLS: o.DebugAPIInsecureTLS,
 		debugAPIScheme:      o.DebugAPIScheme,
@@ -64,6 +81,7 @@ func NewCluster(name string, o ClusterOptions) *Cluster {
 		labels:              o.Labels,
 		namespace:           o.Namespace,
 		disableNamespace:    o.DisableNamespace,
+		tlsRootCAs:          o.TLSRootCAs,
 
 		nodeGroups: make(map[string]*NodeGroup),
 	}
@@ -164,6 +182,16 @@ func (c *Cluster) Name() string {
 	return c.name
 }
 
+// Namespace returns namespace of the cluster
+func (c *Cluster) Namespace() string {
+	return c.namespace
+}
+
+// SwapClient returns client of the chain nodes are funded on
+func (c *Cluster) SwapClient() swap.Client {
+	return c.swap
+}
+
 // NodeGroups returns map of node groups in the cluster
 func (c *Cluster) NodeGroups() (l map[string]*NodeGroup) {
 	return c.nodeGroups
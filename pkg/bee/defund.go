@@ -0,0 +1,162 @@
+package bee
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/keys"
+	"github.com/ethersphere/beekeeper/pkg/swap"
+)
+
+// DefundOptions represents options for recovering funds from cluster's nodes
+type DefundOptions struct {
+	// Treasury is the address funds are sent to
+	Treasury string
+	// NewSweeper returns Sweeper that signs transactions with the hex encoded private key
+	NewSweeper func(ctx context.Context, privateKey string) (swap.Sweeper, error)
+}
+
+// DefundReport represents funds recovered from the node, amounts are in BZZ and ETH
+type DefundReport struct {
+	NodeGroup          string
+	Node               string
+	Address            string
+	ChequebookWithdraw float64
+	ChequebookTx       string
+	BZZ                float64
+	BZZTx              string
+	ETH                float64
+	ETHTx              string
+	// Skipped is the reason node's wallet was not swept
+	Skipped string
+	Err     error
+}
+
+// Defund withdraws chequebook balances of all running nodes in the cluster and
+// sweeps their wallets' BZZ and ETH to the treasury; nodes are defunded
+// concurrently and reports are sorted by node group and node name
+func (c *Cluster) Defund(ctx context.Context, o DefundOptions) (reports []DefundReport, err error) {
+	addrs, err := c.Addresses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("addresses: %w", err)
+	}
+
+	for _, ng := range c.NodeGroupsSorted() {
+		nodes := make([]string, 0, len(addrs[ng]))
+		for n := range addrs[ng] {
+			nodes = append(nodes, n)
+		}
+		sort.Strings(nodes)
+
+		for _, n := range nodes {
+			reports = append(reports, DefundReport{NodeGroup: ng, Node: n, Address: addrs[ng][n].Ethereum})
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range reports {
+		wg.Add(1)
+		go func(r *DefundReport) {
+			defer wg.Done()
+			r.Err = c.nodeGroups[r.NodeGroup].defundNode(ctx, r, o)
+		}(&reports[i])
+	}
+	wg.Wait()
+
+	return
+}
+
+// defundNode withdraws node's chequebook balance and sweeps its wallet to the treasury
+func (g *NodeGroup) defundNode(ctx context.Context, r *DefundReport, o DefundOptions) (err error) {
+	n, err := g.Node(r.Node)
+	if err != nil {
+		return err
+	}
+
+	b, err := n.Client().ChequebookBalance(ctx)
+	if err != nil {
+		return err
+	}
+
+	if b.AvailableBalance.Sign() > 0 {
+		if r.ChequebookTx, err = n.Client().ChequebookWithdraw(ctx, b.AvailableBalance); err != nil {
+			return err
+		}
+		r.ChequebookWithdraw = toCurrency(b.AvailableBalance, 16)
+
+		// withdrawal must be mined before tokens can be swept from the wallet
+		if err := waitChequebookBalance(ctx, n.Client(), new(big.Int).Sub(b.TotalBalance, b.AvailableBalance)); err != nil {
+			return err
+		}
+	}
+
+	key, err := n.walletKey()
+	if err != nil {
+		return err
+	}
+	if len(key) == 0 {
+		r.Skipped = "wallet key unknown"
+		return
+	}
+
+	s, err := o.NewSweeper(ctx, key)
+	if err != nil {
+		return fmt.Errorf("sweeper: %w", err)
+	}
+	defer s.Close()
+
+	// BZZ is swept first as its transfer is paid with ETH
+	tx, amount, err := s.SweepBZZ(ctx, o.Treasury)
+	if err != nil {
+		return fmt.Errorf("sweep bzz: %w", err)
+	}
+	r.BZZTx, r.BZZ = tx, toCurrency(amount, 16)
+
+	tx, amount, err = s.SweepETH(ctx, o.Treasury)
+	if err != nil {
+		return fmt.Errorf("sweep eth: %w", err)
+	}
+	r.ETHTx, r.ETH = tx, toCurrency(amount, 18)
+
+	return
+}
+
+// waitChequebookBalance waits until node's chequebook total balance drops to the target
+func waitChequebookBalance(ctx context.Context, c *Client, target *big.Int) (err error) {
+	for {
+		b, err := c.ChequebookBalance(ctx)
+		if err != nil {
+			return err
+		}
+		if b.TotalBalance.Cmp(target) <= 0 {
+			return nil
+		}
+
+		select {
+		case <-time.After(nodeRetryTimeout):
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for chequebook withdrawal: %w", ctx.Err())
+		}
+	}
+}
+
+// walletKey returns hex encoded private key of node's Ethereum wallet, it is
+// the clef key when clef signer is enabled, otherwise the swarm key; empty
+// key is returned if the key is not known
+func (n *Node) walletKey() (key string, err error) {
+	if n.config != nil && n.config.ClefSignerEnable {
+		if len(n.clefKey) == 0 {
+			return
+		}
+		return keys.Decrypt(n.clefKey, n.clefPassword)
+	}
+
+	if len(n.swarmKey) == 0 || n.config == nil {
+		return
+	}
+	return keys.Decrypt(n.swarmKey, n.config.Password)
+}
@@ -0,0 +1,259 @@
+package bee
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethersphere/beekeeper/pkg/k8s"
+	"github.com/ethersphere/beekeeper/pkg/keys"
+	"github.com/ethersphere/beekeeper/pkg/swap"
+)
+
+func TestDefund(t *testing.T) {
+	const (
+		treasury = "0x62cab2b3b55f341f10348720ca18063cdb779ad5"
+		password = "password"
+	)
+
+	k, err := keys.Generate("bee-0", keys.Options{Seed: "cluster-seed", Password: password})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCluster("test", ClusterOptions{})
+	c.AddNodeGroup("bee", NodeGroupOptions{})
+	g, err := c.NodeGroup("bee")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// bee-0 has a known key and chequebook balance, bee-1 has neither
+	g.addNode(NewNode("bee-0", NodeOptions{
+		Client:   newDefundClient(t, k.Ethereum, big.NewInt(3e16), big.NewInt(2e16)),
+		Config:   &k8s.Config{Password: password},
+		SwarmKey: k.SwarmKey,
+	}))
+	g.addNode(NewNode("bee-1", NodeOptions{
+		Client: newDefundClient(t, "0x1111111111111111111111111111111111111111", big.NewInt(0), big.NewInt(0)),
+		Config: &k8s.Config{Password: password},
+	}))
+
+	s := &defundSweeperMock{}
+	reports, err := c.Defund(context.Background(), DefundOptions{
+		Treasury: treasury,
+		NewSweeper: func(ctx context.Context, privateKey string) (swap.Sweeper, error) {
+			s.setKey(privateKey)
+			return s, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reports) != 2 {
+		t.Fatalf("got %d reports, want 2", len(reports))
+	}
+
+	r := reports[0]
+	if r.Node != "bee-0" || r.NodeGroup != "bee" || r.Address != k.Ethereum {
+		t.Fatalf("got report of node %s in group %s with address %s", r.Node, r.NodeGroup, r.Address)
+	}
+	if r.Err != nil {
+		t.Fatal(r.Err)
+	}
+	if r.ChequebookWithdraw != 2 || r.ChequebookTx != "0xwithdraw" {
+		t.Fatalf("got chequebook withdrawal %v BZZ in %s, want 2 BZZ in 0xwithdraw", r.ChequebookWithdraw, r.ChequebookTx)
+	}
+	if r.BZZ != 1 || r.BZZTx != "0xbzz" || r.ETH != 0.5 || r.ETHTx != "0xeth" {
+		t.Fatalf("got swept %v BZZ in %s and %v ETH in %s", r.BZZ, r.BZZTx, r.ETH, r.ETHTx)
+	}
+	if len(r.Skipped) > 0 {
+		t.Fatalf("wallet skipped: %s", r.Skipped)
+	}
+	if got := keyAddress(t, s.key); got != k.Ethereum {
+		t.Fatalf("sweeper got key of address %s, want %s", got, k.Ethereum)
+	}
+	if s.to != treasury || !s.closed {
+		t.Fatalf("got sweep to %s and sweeper closed %t", s.to, s.closed)
+	}
+
+	r = reports[1]
+	if r.Node != "bee-1" {
+		t.Fatalf("got report of node %s, want bee-1", r.Node)
+	}
+	if r.Err != nil {
+		t.Fatal(r.Err)
+	}
+	if r.Skipped != "wallet key unknown" || len(r.ChequebookTx) > 0 || len(r.BZZTx) > 0 {
+		t.Fatalf("got skipped %q, chequebook tx %q and bzz tx %q", r.Skipped, r.ChequebookTx, r.BZZTx)
+	}
+}
+
+func TestWalletKey(t *testing.T) {
+	const password = "password"
+
+	k, err := keys.Generate("bee-0", keys.Options{Seed: "cluster-seed", Password: password})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kc, err := keys.Generate("bee-0", keys.Options{Seed: "cluster-seed", Password: password, ClefEnabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		o       NodeOptions
+		want    string // address of the returned key
+		wantErr bool
+	}{
+		{
+			name: "swarm key",
+			o:    NodeOptions{Config: &k8s.Config{Password: password}, SwarmKey: k.SwarmKey},
+			want: k.Ethereum,
+		},
+		{
+			name:    "swarm key wrong password",
+			o:       NodeOptions{Config: &k8s.Config{Password: "wrong"}, SwarmKey: k.SwarmKey},
+			wantErr: true,
+		},
+		{
+			name: "swarm key without config",
+			o:    NodeOptions{SwarmKey: k.SwarmKey},
+		},
+		{
+			name: "no swarm key",
+			o:    NodeOptions{Config: &k8s.Config{Password: password}},
+		},
+		{
+			name: "clef key",
+			o: NodeOptions{
+				Config:       &k8s.Config{ClefSignerEnable: true, Password: password},
+				SwarmKey:     kc.SwarmKey,
+				ClefKey:      kc.ClefKey,
+				ClefPassword: kc.ClefPassword,
+			},
+			want: kc.Ethereum,
+		},
+		{
+			name: "clef enabled without clef key",
+			o:    NodeOptions{Config: &k8s.Config{ClefSignerEnable: true, Password: password}, SwarmKey: kc.SwarmKey},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := NewNode("bee-0", tc.o).walletKey()
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(tc.want) == 0 {
+				if len(key) > 0 {
+					t.Fatal("got key, want none")
+				}
+				return
+			}
+			if got := keyAddress(t, key); got != tc.want {
+				t.Fatalf("got key of address %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+// newDefundClient returns client of the debug API which serves node's
+// address and chequebook, withdrawal is mined immediately
+func newDefundClient(t *testing.T, address string, total, available *big.Int) *Client {
+	t.Helper()
+
+	var mu sync.Mutex
+	mux := http.NewServeMux()
+	mux.HandleFunc("/addresses", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]string{"ethereum": address})
+	})
+	mux.HandleFunc("/chequebook/balance", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		writeJSON(t, w, map[string]string{"totalBalance": total.String(), "availableBalance": available.String()})
+	})
+	mux.HandleFunc("/chequebook/withdraw", func(w http.ResponseWriter, r *http.Request) {
+		amount, ok := new(big.Int).SetString(r.URL.Query().Get("amount"), 10)
+		if r.Method != http.MethodPost || !ok {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		total = new(big.Int).Sub(total, amount)
+		available = new(big.Int).Sub(available, amount)
+		mu.Unlock()
+		writeJSON(t, w, map[string]string{"transactionHash": "0xwithdraw"})
+	})
+
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(ClientOptions{DebugAPIURL: u})
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Error(err)
+	}
+}
+
+// keyAddress returns Ethereum address of the hex encoded private key
+func keyAddress(t *testing.T, key string) string {
+	t.Helper()
+
+	k, err := crypto.HexToECDSA(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crypto.PubkeyToAddress(k.PublicKey).Hex()
+}
+
+type defundSweeperMock struct {
+	mu     sync.Mutex
+	key    string
+	to     string
+	closed bool
+}
+
+func (s *defundSweeperMock) setKey(key string) {
+	s.mu.Lock()
+	s.key = key
+	s.mu.Unlock()
+}
+
+func (s *defundSweeperMock) SweepBZZ(ctx context.Context, to string) (string, *big.Int, error) {
+	s.mu.Lock()
+	s.to = to
+	s.mu.Unlock()
+	return "0xbzz", big.NewInt(1e16), nil
+}
+
+func (s *defundSweeperMock) SweepETH(ctx context.Context, to string) (string, *big.Int, error) {
+	return "0xeth", big.NewInt(5e17), nil
+}
+
+func (s *defundSweeperMock) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+}
@@ -2,6 +2,7 @@ package bee
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"hash"
 	"io"
@@ -11,6 +12,9 @@ import (
 	"golang.org/x/crypto/sha3"
 )
 
+// ErrFileMismatch is returned when downloaded file or data differs from the uploaded one
+var ErrFileMismatch = errors.New("downloaded file differs")
+
 // File represents Bee file
 type File struct {
 	address    swarm.Address
@@ -18,6 +22,9 @@ type File struct {
 	hash       []byte
 	dataReader io.Reader
 	size       int64
+	// seeded file's content is generated from the seed on every read
+	seeded bool
+	seed   int64
 }
 
 // NewRandomFile returns new pseudorandom file
@@ -29,6 +36,18 @@ func NewRandomFile(r *rand.Rand, name string, size int64) File {
 	}
 }
 
+// NewSeededFile returns new pseudorandom file whose content is generated from
+// the seed while it is read, the content is never held in memory and can be
+// read again, so files of any size can be uploaded and verified
+func NewSeededFile(seed int64, name string, size int64) File {
+	return File{
+		name:   name,
+		size:   size,
+		seeded: true,
+		seed:   seed,
+	}
+}
+
 // NewBufferFile returns new file with specified buffer
 func NewBufferFile(name string, buffer *bytes.Buffer) File {
 	return File{
@@ -39,10 +58,19 @@ func NewBufferFile(name string, buffer *bytes.Buffer) File {
 }
 
 // CalculateHash calculates hash from dataReader.
-// It replaces dataReader with another that will contain the data.
+// It replaces dataReader with another that will contain the data, seeded
+// file's content is hashed without buffering as it can be generated again.
 func (f *File) CalculateHash() error {
 	h := fileHasher()
 
+	if f.seeded {
+		if _, err := io.Copy(h, f.DataReader()); err != nil {
+			return err
+		}
+		f.hash = h.Sum(nil)
+		return nil
+	}
+
 	var buf bytes.Buffer
 	tee := io.TeeReader(f.DataReader(), &buf)
 
@@ -72,8 +100,12 @@ func (f *File) Hash() []byte {
 	return f.hash
 }
 
-// DataReader returns file's data reader
+// DataReader returns file's data reader, seeded file returns new reader of
+// its whole content on every call
 func (f *File) DataReader() io.Reader {
+	if f.seeded {
+		return io.LimitReader(rand.New(rand.NewSource(f.seed)), f.size)
+	}
 	return f.dataReader
 }
 
@@ -105,6 +137,52 @@ func (f *File) ClosestNode(nodes []swarm.Address) (closest swarm.Address, err er
 	return
 }
 
+// compareReaders reads both readers to the end or to the first difference and
+// returns its offset, offset is -1 if readers have the same content
+func compareReaders(a, b io.Reader) (offset int64, err error) {
+	var n int64
+	bufA, bufB := make([]byte, 32*1024), make([]byte, 32*1024)
+	for {
+		m, errA := io.ReadFull(a, bufA)
+		if errA != nil && errA != io.EOF && errA != io.ErrUnexpectedEOF {
+			return -1, errA
+		}
+		k, errB := io.ReadFull(b, bufB[:m])
+		if errB != nil && errB != io.EOF && errB != io.ErrUnexpectedEOF {
+			return -1, errB
+		}
+		if i := firstDifference(bufA[:m], bufB[:k]); i >= 0 {
+			return n + int64(i), nil
+		}
+		n += int64(m)
+
+		if errA != nil {
+			// a ended, b has to end too
+			if k, _ := io.ReadFull(b, bufB[:1]); k > 0 {
+				return n, nil
+			}
+			return -1, nil
+		}
+	}
+}
+
+// firstDifference returns index of the first difference of the slices or -1
+// if they are equal
+func firstDifference(a, b []byte) int {
+	if bytes.Equal(a, b) {
+		return -1
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	if len(a) < len(b) {
+		return len(a)
+	}
+	return len(b)
+}
+
 func fileHasher() hash.Hash {
 	return sha3.New256()
 }
@@ -0,0 +1,134 @@
+package bee
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+)
+
+func TestFirstDifference(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		a, b []byte
+		want int
+	}{
+		{name: "equal", a: []byte("abc"), b: []byte("abc"), want: -1},
+		{name: "empty", a: nil, b: []byte{}, want: -1},
+		{name: "first byte", a: []byte("abc"), b: []byte("xbc"), want: 0},
+		{name: "last byte", a: []byte("abc"), b: []byte("abx"), want: 2},
+		{name: "shorter", a: []byte("ab"), b: []byte("abc"), want: 2},
+		{name: "longer", a: []byte("abc"), b: []byte("ab"), want: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := firstDifference(tc.a, tc.b); got != tc.want {
+				t.Fatalf("got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompareReaders(t *testing.T) {
+	const block = 32 * 1024
+
+	data := make([]byte, 3*block)
+	f := NewSeededFile(1, "data", int64(len(data)))
+	if _, err := io.ReadFull(f.DataReader(), data); err != nil {
+		t.Fatal(err)
+	}
+	changed := func(offset int) []byte {
+		d := append([]byte(nil), data...)
+		d[offset] ^= 0xff
+		return d
+	}
+
+	for _, tc := range []struct {
+		name string
+		a, b []byte
+		want int64
+	}{
+		{name: "equal", a: data, b: data, want: -1},
+		{name: "empty", a: nil, b: nil, want: -1},
+		{name: "first byte", a: data, b: changed(0), want: 0},
+		{name: "last byte of block", a: data, b: changed(block - 1), want: block - 1},
+		{name: "first byte of block", a: data, b: changed(block), want: block},
+		{name: "last byte", a: data, b: changed(len(data) - 1), want: int64(len(data) - 1)},
+		{name: "shorter", a: data[:block+10], b: data, want: block + 10},
+		{name: "longer", a: data, b: data[:block+10], want: block + 10},
+		{name: "shorter at block boundary", a: data[:block], b: data, want: block},
+		{name: "longer at block boundary", a: data, b: data[:block], want: block},
+		{name: "empty and not empty", a: nil, b: data, want: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// readers returning one byte at a time must give the same result
+			for _, r := range []struct {
+				name string
+				wrap func(io.Reader) io.Reader
+			}{
+				{name: "whole", wrap: func(r io.Reader) io.Reader { return r }},
+				{name: "one byte", wrap: iotest.OneByteReader},
+			} {
+				got, err := compareReaders(r.wrap(bytes.NewReader(tc.a)), r.wrap(bytes.NewReader(tc.b)))
+				if err != nil {
+					t.Fatalf("%s reads: %v", r.name, err)
+				}
+				if got != tc.want {
+					t.Fatalf("%s reads: got offset %d, want %d", r.name, got, tc.want)
+				}
+			}
+		})
+	}
+
+	if _, err := compareReaders(iotest.TimeoutReader(bytes.NewReader(data)), bytes.NewReader(data)); err == nil {
+		t.Fatal("expected read error")
+	}
+}
+
+func TestNewSeededFile(t *testing.T) {
+	const size = 100 * 1024
+
+	f := NewSeededFile(1, "file", size)
+	first, err := ioutil.ReadAll(f.DataReader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != size || f.Size() != size {
+		t.Fatalf("got %d bytes and size %d, want %d", len(first), f.Size(), size)
+	}
+
+	// content is generated again on every read
+	second, err := ioutil.ReadAll(f.DataReader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Fatal("got different content on the second read")
+	}
+
+	same := NewSeededFile(1, "same", size)
+	if err := f.CalculateHash(); err != nil {
+		t.Fatal(err)
+	}
+	if err := same.CalculateHash(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(f.Hash(), same.Hash()) {
+		t.Fatal("got different hashes for the same seed")
+	}
+
+	// hash of seeded file is hash of its content
+	h := fileHasher()
+	_, _ = h.Write(first)
+	if !bytes.Equal(f.Hash(), h.Sum(nil)) {
+		t.Fatal("got hash that doesn't match content")
+	}
+
+	other := NewSeededFile(2, "other", size)
+	if err := other.CalculateHash(); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(f.Hash(), other.Hash()) {
+		t.Fatal("got the same hash for different seeds")
+	}
+}
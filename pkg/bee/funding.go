@@ -0,0 +1,203 @@
+package bee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// CurrencyETH is ETH currency used in funding deposits
+	CurrencyETH = "ETH"
+	// CurrencyBZZ is BZZ token currency used in funding deposits, tokens are minted
+	CurrencyBZZ = "BZZ"
+	// CurrencyGBZZ is gBZZ token currency used in funding deposits, tokens are transferred
+	CurrencyGBZZ = "gBZZ"
+
+	// fundingPollInterval is interval in which deposit's receipt is polled
+	fundingPollInterval = time.Second
+	// fundingMineTimeout is time within which deposit has to be mined
+	fundingMineTimeout = 5 * time.Minute
+)
+
+// FundingDeposit represents deposit needed to top up node's balance to the target
+type FundingDeposit struct {
+	NodeGroup string
+	Node      string
+	Address   string
+	Currency  string
+	Balance   float64
+	Target    float64
+	Amount    float64
+}
+
+// FundingPlan represents deposits needed to top up all nodes in the cluster
+type FundingPlan []FundingDeposit
+
+// FundingReceipt represents result of the funding deposit
+type FundingReceipt struct {
+	FundingDeposit
+	Tx  string
+	Err error
+}
+
+// FundingPlan returns deposits needed to top up balances of all nodes in the
+// cluster to the node group targets, node groups without targets are skipped
+func (c *Cluster) FundingPlan(ctx context.Context, targets map[string]FundingOptions) (plan FundingPlan, err error) {
+	addrs, err := c.Addresses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("addresses: %w", err)
+	}
+
+	for _, ng := range c.NodeGroupsSorted() {
+		t, ok := targets[ng]
+		if !ok {
+			continue
+		}
+
+		nodes := make([]string, 0, len(addrs[ng]))
+		for n := range addrs[ng] {
+			nodes = append(nodes, n)
+		}
+		sort.Strings(nodes)
+
+		for _, n := range nodes {
+			deposits, err := c.fundingDeposits(ctx, addrs[ng][n].Ethereum, t)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", n, err)
+			}
+
+			for _, d := range deposits {
+				d.NodeGroup = ng
+				d.Node = n
+				plan = append(plan, d)
+			}
+		}
+	}
+
+	return
+}
+
+// Fund makes all deposits from the plan that have positive amount and waits
+// until they are mined, deposits are made concurrently and receipts are
+// returned in the plan's order
+func (c *Cluster) Fund(ctx context.Context, plan FundingPlan) (receipts []FundingReceipt) {
+	receipts = make([]FundingReceipt, len(plan))
+
+	var wg sync.WaitGroup
+	for i, d := range plan {
+		receipts[i].FundingDeposit = d
+		if d.Amount <= 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, d FundingDeposit) {
+			defer wg.Done()
+			if receipts[i].Tx, receipts[i].Err = c.deposit(ctx, d); receipts[i].Err != nil {
+				return
+			}
+			receipts[i].Err = c.waitMined(ctx, receipts[i].Tx)
+		}(i, d)
+	}
+	wg.Wait()
+
+	return
+}
+
+// fundingDeposits reads on-chain balances of the address and returns deposits
+// needed to top them up to the targets; BZZ and gBZZ are the same token, so
+// the BZZ target is used if both are set
+func (c *Cluster) fundingDeposits(ctx context.Context, address string, o FundingOptions) (deposits []FundingDeposit, err error) {
+	if o.Eth > 0 {
+		balance, err := c.swap.ETHBalance(ctx, address)
+		if err != nil {
+			return nil, fmt.Errorf("eth balance: %w", err)
+		}
+		deposits = append(deposits, newFundingDeposit(address, CurrencyETH, balance, 18, o.Eth))
+	}
+
+	if o.Bzz > 0 || o.GBzz > 0 {
+		balance, err := c.swap.BZZBalance(ctx, address)
+		if err != nil {
+			return nil, fmt.Errorf("bzz balance: %w", err)
+		}
+		if o.Bzz > 0 {
+			deposits = append(deposits, newFundingDeposit(address, CurrencyBZZ, balance, 16, o.Bzz))
+		} else {
+			deposits = append(deposits, newFundingDeposit(address, CurrencyGBZZ, balance, 16, o.GBzz))
+		}
+	}
+
+	return
+}
+
+// deposit sends deposit's amount to its address
+func (c *Cluster) deposit(ctx context.Context, d FundingDeposit) (tx string, err error) {
+	switch d.Currency {
+	case CurrencyETH:
+		return c.swap.SendETH(ctx, d.Address, d.Amount)
+	case CurrencyBZZ:
+		return c.swap.SendBZZ(ctx, d.Address, d.Amount)
+	case CurrencyGBZZ:
+		return c.swap.SendGBZZ(ctx, d.Address, d.Amount)
+	default:
+		return "", fmt.Errorf("unsupported currency %s", d.Currency)
+	}
+}
+
+// waitMined waits until the deposit transaction is mined, as swap clients
+// return before that, so balances read afterwards include the deposit
+func (c *Cluster) waitMined(ctx context.Context, tx string) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, fundingMineTimeout)
+	defer cancel()
+
+	for {
+		r, err := c.swap.TransactionReceipt(ctx, tx)
+		if err == nil {
+			if r.Status != types.ReceiptStatusSuccessful {
+				return fmt.Errorf("transaction %s failed", tx)
+			}
+			return nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return fmt.Errorf("transaction %s receipt: %w", tx, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("transaction %s not mined: %w", tx, ctx.Err())
+		case <-time.After(fundingPollInterval):
+		}
+	}
+}
+
+// newFundingDeposit returns deposit for the balance in the currency's base units
+func newFundingDeposit(address, currency string, balance *big.Int, decimals int64, target float64) (d FundingDeposit) {
+	b := toCurrency(balance, decimals)
+
+	d = FundingDeposit{
+		Address:  address,
+		Currency: currency,
+		Balance:  b,
+		Target:   target,
+	}
+	if target > b {
+		d.Amount = target - b
+	}
+
+	return
+}
+
+// toCurrency converts amount in currency's base units to the currency
+func toCurrency(amount *big.Int, decimals int64) float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil))).Float64()
+	return f
+}
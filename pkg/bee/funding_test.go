@@ -0,0 +1,155 @@
+package bee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestTopUp(t *testing.T) {
+	const address = "0x62cab2b3b55f341f10348720ca18063cdb779ad5"
+
+	for _, tc := range []struct {
+		name      string
+		pending   int // receipt polls before transaction is mined
+		failed    bool
+		wantErr   bool
+		wantSends int
+	}{
+		{
+			name:      "mined immediately",
+			wantSends: 2,
+		},
+		{
+			name:      "mined after polls",
+			pending:   2,
+			wantSends: 2,
+		},
+		{
+			name:      "failed transaction",
+			failed:    true,
+			wantErr:   true,
+			wantSends: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &fundingSwapMock{
+				balances: map[string]*big.Int{CurrencyETH: big.NewInt(0), CurrencyBZZ: big.NewInt(0)},
+				pending:  tc.pending,
+				failed:   tc.failed,
+			}
+			g := &NodeGroup{cluster: &Cluster{swap: s}}
+			o := FundingOptions{Eth: 1, Bzz: 2}
+
+			err := g.topUp(context.Background(), "bee-0", address, o)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// second top up, as done on retry, reads mined balances and
+			// makes no deposits
+			if err := g.topUp(context.Background(), "bee-0", address, o); err != nil {
+				t.Fatal(err)
+			}
+			if s.sends != tc.wantSends {
+				t.Fatalf("got %d deposits, want %d", s.sends, tc.wantSends)
+			}
+			if got := toCurrency(s.balances[CurrencyETH], 18); got != o.Eth {
+				t.Fatalf("got ETH balance %v, want %v", got, o.Eth)
+			}
+			if got := toCurrency(s.balances[CurrencyBZZ], 16); got != o.Bzz {
+				t.Fatalf("got BZZ balance %v, want %v", got, o.Bzz)
+			}
+		})
+	}
+}
+
+// fundingSwapMock is Swap client whose deposits change balances only after
+// their receipts are polled as pending the set number of times
+type fundingSwapMock struct {
+	balances map[string]*big.Int
+	pending  int
+	failed   bool
+
+	mu    sync.Mutex
+	sends int
+	txs   map[string]*fundingTx
+}
+
+type fundingTx struct {
+	currency string
+	amount   *big.Int
+	polls    int
+}
+
+func (m *fundingSwapMock) ETHBalance(ctx context.Context, address string) (*big.Int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return new(big.Int).Set(m.balances[CurrencyETH]), nil
+}
+
+func (m *fundingSwapMock) BZZBalance(ctx context.Context, address string) (*big.Int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return new(big.Int).Set(m.balances[CurrencyBZZ]), nil
+}
+
+func (m *fundingSwapMock) TransactionReceipt(ctx context.Context, tx string) (*types.Receipt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.txs[tx]
+	if !ok {
+		return nil, errors.New("unknown transaction")
+	}
+	if t.polls < m.pending {
+		t.polls++
+		return nil, ethereum.NotFound
+	}
+	if m.failed {
+		return &types.Receipt{Status: types.ReceiptStatusFailed}, nil
+	}
+	if t.amount != nil {
+		m.balances[t.currency].Add(m.balances[t.currency], t.amount)
+		t.amount = nil
+	}
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+}
+
+func (m *fundingSwapMock) SendETH(ctx context.Context, to string, amount float64) (string, error) {
+	return m.send(CurrencyETH, amount, 18)
+}
+
+func (m *fundingSwapMock) SendBZZ(ctx context.Context, to string, amount float64) (string, error) {
+	return m.send(CurrencyBZZ, amount, 16)
+}
+
+func (m *fundingSwapMock) SendGBZZ(ctx context.Context, to string, amount float64) (string, error) {
+	return m.send(CurrencyBZZ, amount, 16)
+}
+
+func (m *fundingSwapMock) send(currency string, amount float64, decimals int64) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, _ := new(big.Float).Mul(big.NewFloat(amount), new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil))).Int(nil)
+	m.sends++
+	tx := fmt.Sprintf("0x%x", m.sends)
+	if m.txs == nil {
+		m.txs = make(map[string]*fundingTx)
+	}
+	m.txs[tx] = &fundingTx{currency: currency, amount: a}
+	return tx, nil
+}
@@ -11,6 +11,7 @@ type Node struct {
 	clefPassword string
 	client       *Client
 	config       *k8s.Config
+	groupOptions *NodeGroupOptions
 	libP2PKey    string
 	swarmKey     string
 }
@@ -21,6 +22,7 @@ type NodeOptions struct {
 	ClefPassword string
 	Client       *Client
 	Config       *k8s.Config
+	GroupOptions *NodeGroupOptions // overrides node group's options for the node
 	LibP2PKey    string
 	SwarmKey     string
 }
@@ -35,6 +37,9 @@ func NewNode(name string, opts NodeOptions) (n *Node) {
 	if opts.Config != nil {
 		n.config = opts.Config
 	}
+	if opts.GroupOptions != nil {
+		n.groupOptions = opts.GroupOptions
+	}
 	if len(opts.ClefKey) > 0 {
 		n.clefKey = opts.ClefKey
 	}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethersphere/bee/pkg/swarm"
 	"github.com/ethersphere/beekeeper/pkg/k8s"
+	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
 )
 
 const nodeRetryTimeout = 3 * time.Second
@@ -28,29 +29,37 @@ type NodeGroup struct {
 
 // NodeGroupOptions represents node group options
 type NodeGroupOptions struct {
-	Annotations               map[string]string
-	ClefImage                 string
-	ClefImagePullPolicy       string
-	BeeConfig                 *k8s.Config
-	Image                     string
-	ImagePullPolicy           string
-	ImagePullSecrets          []string
-	IngressAnnotations        map[string]string
-	IngressClass              string
-	IngressDebugAnnotations   map[string]string
-	IngressDebugClass         string
-	Labels                    map[string]string
-	NodeSelector              map[string]string
-	PersistenceEnabled        bool
-	PersistenceStorageClass   string
-	PersistenceStorageRequest string
-	PodManagementPolicy       string
-	RestartPolicy             string
-	ResourcesLimitCPU         string
-	ResourcesLimitMemory      string
-	ResourcesRequestCPU       string
-	ResourcesRequestMemory    string
-	UpdateStrategy            string
+	Affinity                     pod.Affinity
+	Annotations                  map[string]string
+	ClefImage                    string
+	ClefImagePullPolicy          string
+	BeeConfig                    *k8s.Config
+	Image                        string
+	ImagePullPolicy              string
+	ImagePullSecrets             []string
+	IngressAnnotations           map[string]string
+	IngressClass                 string
+	IngressTLSClusterIssuer      string
+	IngressTLSSecret             string
+	IngressDebugAnnotations      map[string]string
+	IngressDebugClass            string
+	IngressDebugTLSClusterIssuer string
+	IngressDebugTLSSecret        string
+	Labels                       map[string]string
+	NodeSelector                 map[string]string
+	PersistenceEnabled           bool
+	PersistenceStorageClass      string
+	PersistenceStorageRequest    string
+	PodManagementPolicy          string
+	PriorityClassName            string
+	RestartPolicy                string
+	ResourcesLimitCPU            string
+	ResourcesLimitMemory         string
+	ResourcesRequestCPU          string
+	ResourcesRequestMemory       string
+	Tolerations                  pod.Tolerations
+	TopologySpreadConstraints    pod.TopologySpreadConstraints
+	UpdateStrategy               string
 }
 
 // NewNodeGroup returns new node group
@@ -79,10 +88,14 @@ func (g *NodeGroup) AddNode(name string, o NodeOptions) (err error) {
 		APIInsecureTLS:      g.cluster.apiInsecureTLS,
 		DebugAPIURL:         dURL,
 		DebugAPIInsecureTLS: g.cluster.debugAPIInsecureTLS,
-		Retry:               5,
+		TLSRootCAs:          g.cluster.tlsRootCAs,
+		Retry:               g.cluster.apiRetries,
+		Timeout:             g.cluster.apiTimeout,
+		MaxConnsPerHost:     g.cluster.apiMaxConnsPerHost,
+		Cassette:            g.cluster.cassette,
+		Name:                name,
 	})
 
-	// TODO: make more granular, check every sub-option
 	var config *k8s.Config
 	if o.Config != nil {
 		config = o.Config
@@ -95,6 +108,7 @@ func (g *NodeGroup) AddNode(name string, o NodeOptions) (err error) {
 		ClefPassword: o.ClefPassword,
 		Client:       client,
 		Config:       config,
+		GroupOptions: o.GroupOptions,
 		LibP2PKey:    o.LibP2PKey,
 		SwarmKey:     o.SwarmKey,
 	})
@@ -252,50 +266,59 @@ func (g *NodeGroup) BalancesStream(ctx context.Context) (<-chan BalancesStreamMs
 
 // CreateNode creates new node in the k8s cluster
 func (g *NodeGroup) CreateNode(ctx context.Context, name string) (err error) {
-	labels := mergeMaps(g.opts.Labels, map[string]string{
-		"app.kubernetes.io/instance": name,
-	})
-
 	n, err := g.getNode(name)
 	if err != nil {
 		return err
 	}
+	o := g.nodeOptions(n)
+
+	labels := mergeMaps(o.Labels, map[string]string{
+		"app.kubernetes.io/instance": name,
+	})
 
 	if err := g.k8s.Create(ctx, k8s.CreateOptions{
 		// Bee configuration
 		Config: *n.config,
 		// Kubernetes configuration
-		Name:                      name,
-		Namespace:                 g.cluster.namespace,
-		Annotations:               g.opts.Annotations,
-		ClefImage:                 g.opts.ClefImage,
-		ClefImagePullPolicy:       g.opts.ClefImagePullPolicy,
-		ClefKey:                   n.clefKey,
-		ClefPassword:              n.clefPassword,
-		Image:                     g.opts.Image,
-		ImagePullPolicy:           g.opts.ImagePullPolicy,
-		ImagePullSecrets:          g.opts.ImagePullSecrets,
-		IngressAnnotations:        g.opts.IngressAnnotations,
-		IngressClass:              g.opts.IngressClass,
-		IngressHost:               g.cluster.ingressHost(name),
-		IngressDebugAnnotations:   g.opts.IngressDebugAnnotations,
-		IngressDebugClass:         g.opts.IngressDebugClass,
-		IngressDebugHost:          g.cluster.ingressDebugHost(name),
-		Labels:                    labels,
-		LibP2PKey:                 n.libP2PKey,
-		NodeSelector:              g.opts.NodeSelector,
-		PersistenceEnabled:        g.opts.PersistenceEnabled,
-		PersistenceStorageClass:   g.opts.PersistenceStorageClass,
-		PersistenceStorageRequest: g.opts.PersistenceStorageRequest,
-		PodManagementPolicy:       g.opts.PodManagementPolicy,
-		RestartPolicy:             g.opts.RestartPolicy,
-		ResourcesLimitCPU:         g.opts.ResourcesLimitCPU,
-		ResourcesLimitMemory:      g.opts.ResourcesLimitMemory,
-		ResourcesRequestCPU:       g.opts.ResourcesRequestCPU,
-		ResourcesRequestMemory:    g.opts.ResourcesRequestMemory,
-		Selector:                  labels,
-		SwarmKey:                  n.swarmKey,
-		UpdateStrategy:            g.opts.UpdateStrategy,
+		Name:                         name,
+		Namespace:                    g.cluster.namespace,
+		Affinity:                     o.Affinity,
+		Annotations:                  o.Annotations,
+		ClefImage:                    o.ClefImage,
+		ClefImagePullPolicy:          o.ClefImagePullPolicy,
+		ClefKey:                      n.clefKey,
+		ClefPassword:                 n.clefPassword,
+		Image:                        o.Image,
+		ImagePullPolicy:              o.ImagePullPolicy,
+		ImagePullSecrets:             o.ImagePullSecrets,
+		IngressAnnotations:           o.IngressAnnotations,
+		IngressClass:                 o.IngressClass,
+		IngressHost:                  g.cluster.ingressHost(name),
+		IngressTLSClusterIssuer:      o.IngressTLSClusterIssuer,
+		IngressTLSSecret:             o.IngressTLSSecret,
+		IngressDebugAnnotations:      o.IngressDebugAnnotations,
+		IngressDebugClass:            o.IngressDebugClass,
+		IngressDebugHost:             g.cluster.ingressDebugHost(name),
+		IngressDebugTLSClusterIssuer: o.IngressDebugTLSClusterIssuer,
+		IngressDebugTLSSecret:        o.IngressDebugTLSSecret,
+		Labels:                       labels,
+		LibP2PKey:                    n.libP2PKey,
+		NodeSelector:                 o.NodeSelector,
+		PersistenceEnabled:           o.PersistenceEnabled,
+		PersistenceStorageClass:      o.PersistenceStorageClass,
+		PersistenceStorageRequest:    o.PersistenceStorageRequest,
+		PodManagementPolicy:          o.PodManagementPolicy,
+		PriorityClassName:            o.PriorityClassName,
+		RestartPolicy:                o.RestartPolicy,
+		ResourcesLimitCPU:            o.ResourcesLimitCPU,
+		ResourcesLimitMemory:         o.ResourcesLimitMemory,
+		ResourcesRequestCPU:          o.ResourcesRequestCPU,
+		ResourcesRequestMemory:       o.ResourcesRequestMemory,
+		Selector:                     labels,
+		SwarmKey:                     n.swarmKey,
+		Tolerations:                  o.Tolerations,
+		TopologySpreadConstraints:    o.TopologySpreadConstraints,
+		UpdateStrategy:               o.UpdateStrategy,
 	}); err != nil {
 		return err
 	}
@@ -314,84 +337,79 @@ func (g *NodeGroup) DeleteNode(ctx context.Context, name string) (err error) {
 	return
 }
 
+// FundingOptions represents funding targets, nodes are topped up to them
 type FundingOptions struct {
 	Eth  float64
 	Bzz  float64
 	GBzz float64
 }
 
-// Fund adds funds to the node
+// Fund tops up node's on-chain balances to the targets, so it is safe to call
+// for already funded nodes
 func (g *NodeGroup) Fund(ctx context.Context, name string, o FundingOptions) (err error) {
+	if o.Eth <= 0 && o.Bzz <= 0 && o.GBzz <= 0 {
+		return
+	}
+
 	var a Addresses
-	if o.Eth > 0 || o.Bzz > 0 || o.GBzz > 0 {
-		retries := 5
-		for {
-			c, err := g.NodeClient(name)
-			if err != nil {
-				return err
-			}
-			a, err = c.Addresses(ctx)
-			if err != nil {
-				retries--
-				if retries == 0 {
-					return fmt.Errorf("get %s address: %w", name, err)
-				}
-				time.Sleep(nodeRetryTimeout)
-				continue
+	retries := 5
+	for {
+		c, err := g.NodeClient(name)
+		if err != nil {
+			return err
+		}
+		a, err = c.Addresses(ctx)
+		if err != nil {
+			retries--
+			if retries == 0 {
+				return fmt.Errorf("get %s address: %w", name, err)
 			}
-			break
+			time.Sleep(nodeRetryTimeout)
+			continue
 		}
+		break
 	}
 
-	if o.Eth > 0 {
-		retries := 5
-		for {
-			tx, err := g.cluster.swap.SendETH(ctx, a.Ethereum, o.Eth)
-			if err != nil {
-				retries--
-				if retries == 0 {
-					return fmt.Errorf("send eth: %w", err)
-				}
-				time.Sleep(nodeRetryTimeout)
-				continue
+	// balances are read again on every retry, so deposits that were mined are
+	// not repeated
+	retries = 5
+	for {
+		if err := g.topUp(ctx, name, a.Ethereum, o); err != nil {
+			retries--
+			if retries == 0 {
+				return fmt.Errorf("top up: %w", err)
 			}
-			fmt.Printf("%s funded with %.2f ETH, transaction: %s\n", name, o.Eth, tx)
-			break
+			time.Sleep(nodeRetryTimeout)
+			continue
 		}
+		break
 	}
 
-	if o.Bzz > 0 {
-		retries := 5
-		for {
-			tx, err := g.cluster.swap.SendBZZ(ctx, a.Ethereum, o.Bzz)
-			if err != nil {
-				retries--
-				if retries == 0 {
-					return fmt.Errorf("send eth: %w", err)
-				}
-				time.Sleep(nodeRetryTimeout)
-				continue
-			}
-			fmt.Printf("%s funded with %.2f BZZ, transaction: %s\n", name, o.Bzz, tx)
-			break
-		}
+	return
+}
+
+// topUp makes deposits needed to top up address' balances to the targets and
+// waits until they are mined
+func (g *NodeGroup) topUp(ctx context.Context, name, address string, o FundingOptions) (err error) {
+	deposits, err := g.cluster.fundingDeposits(ctx, address, o)
+	if err != nil {
+		return err
 	}
 
-	if o.GBzz > 0 {
-		retries := 5
-		for {
-			tx, err := g.cluster.swap.SendGBZZ(ctx, a.Ethereum, o.GBzz)
-			if err != nil {
-				retries--
-				if retries == 0 {
-					return fmt.Errorf("send eth: %w", err)
-				}
-				time.Sleep(nodeRetryTimeout)
-				continue
-			}
-			fmt.Printf("%s funded with %.2f gBZZ, transaction: %s\n", name, o.GBzz, tx)
-			break
+	for _, d := range deposits {
+		if d.Amount <= 0 {
+			fmt.Printf("%s has %.2f %s, target %.2f reached\n", name, d.Balance, d.Currency, d.Target)
+			continue
 		}
+
+		tx, err := g.cluster.deposit(ctx, d)
+		if err != nil {
+			return fmt.Errorf("send %s: %w", d.Currency, err)
+		}
+		if err := g.cluster.waitMined(ctx, tx); err != nil {
+			return fmt.Errorf("send %s: %w", d.Currency, err)
+		}
+		fmt.Printf("%s funded with %.2f %s, transaction: %s\n", name, d.Amount, d.Currency, tx)
 	}
 
 	return
@@ -656,11 +674,55 @@ func (g *NodeGroup) PeersStream(ctx context.Context) (<-chan PeersStreamMsg, err
 	return peersStream, nil
 }
 
+// NodeImage returns Bee image the node is configured with
+func (g *NodeGroup) NodeImage(name string) (string, error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return "", err
+	}
+
+	return g.nodeOptions(n).Image, nil
+}
+
 // NodeReady returns node's readiness
 func (g *NodeGroup) NodeReady(ctx context.Context, name string) (ok bool, err error) {
 	return g.k8s.Ready(ctx, name, g.cluster.namespace)
 }
 
+// RestoreNode creates node in the k8s cluster with data volume, keys and
+// configuration restored from the snapshot and starts it
+func (g *NodeGroup) RestoreNode(ctx context.Context, name, snapshot string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+	o := g.nodeOptions(n)
+
+	if !o.PersistenceEnabled {
+		return fmt.Errorf("restore node %s: persistence is not enabled", name)
+	}
+
+	if err := g.CreateNode(ctx, name); err != nil {
+		return fmt.Errorf("create node %s in k8s: %w", name, err)
+	}
+
+	if err := g.k8s.Restore(ctx, name, g.cluster.namespace, k8s.RestoreOptions{
+		Snapshot:                  snapshot,
+		Annotations:               o.Annotations,
+		Labels:                    mergeMaps(o.Labels, map[string]string{"app.kubernetes.io/instance": name}),
+		PersistenceStorageClass:   o.PersistenceStorageClass,
+		PersistenceStorageRequest: o.PersistenceStorageRequest,
+	}); err != nil {
+		return fmt.Errorf("restore node %s from snapshot %s: %w", name, snapshot, err)
+	}
+
+	if err := g.StartNode(ctx, name); err != nil {
+		return fmt.Errorf("start node %s in k8s: %w", name, err)
+	}
+
+	return
+}
+
 // RunningNodes returns list of running nodes
 func (g *NodeGroup) RunningNodes(ctx context.Context) (running []string, err error) {
 	allRunning, err := g.k8s.RunningNodes(ctx, g.cluster.namespace)
@@ -785,6 +847,45 @@ func (g *NodeGroup) SettlementsStream(ctx context.Context) (<-chan SettlementsSt
 	return SettlementsStream, nil
 }
 
+// SnapshotNode creates snapshot of node's data volume, keys and configuration
+// and waits for the snapshot to become ready
+func (g *NodeGroup) SnapshotNode(ctx context.Context, name, snapshot, volumeSnapshotClass string) (err error) {
+	n, err := g.getNode(name)
+	if err != nil {
+		return err
+	}
+	o := g.nodeOptions(n)
+
+	if !o.PersistenceEnabled {
+		return fmt.Errorf("snapshot node %s: persistence is not enabled", name)
+	}
+
+	if err := g.k8s.Snapshot(ctx, name, g.cluster.namespace, k8s.SnapshotOptions{
+		Snapshot:            snapshot,
+		Annotations:         o.Annotations,
+		Labels:              o.Labels,
+		VolumeSnapshotClass: volumeSnapshotClass,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("wait for %s snapshot %s to become ready\n", name, snapshot)
+	for {
+		ok, err := g.k8s.SnapshotReady(ctx, name, g.cluster.namespace, snapshot)
+		if err != nil {
+			return fmt.Errorf("node %s snapshot %s readiness: %w", name, snapshot, err)
+		}
+
+		if ok {
+			fmt.Printf("%s snapshot %s is ready\n", name, snapshot)
+			return nil
+		}
+
+		fmt.Printf("%s snapshot %s is not ready yet\n", name, snapshot)
+		time.Sleep(nodeRetryTimeout)
+	}
+}
+
 // Size returns size of the node group
 func (g *NodeGroup) Size() int {
 	return len(g.nodes)
@@ -960,6 +1061,18 @@ func (g *NodeGroup) getNode(name string) (n *Node, err error) {
 	return
 }
 
+// nodeOptions returns node group options of the node, they are overridden if the node has its own
+func (g *NodeGroup) nodeOptions(n *Node) NodeGroupOptions {
+	if n.groupOptions == nil {
+		return g.opts
+	}
+
+	o := *n.groupOptions
+	o.Annotations = mergeMaps(g.cluster.annotations, o.Annotations)
+	o.Labels = mergeMaps(g.cluster.labels, o.Labels)
+	return o
+}
+
 func (g *NodeGroup) getNodes() map[string]*Node {
 	g.lock.RLock()
 	nodes := g.nodes
@@ -0,0 +1,90 @@
+package bee
+
+import "testing"
+
+func TestNodeOptions(t *testing.T) {
+	c := NewCluster("test", ClusterOptions{
+		APIDomain:      "localhost",
+		APIScheme:      "http",
+		DebugAPIDomain: "localhost",
+		DebugAPIScheme: "http",
+		Labels:         map[string]string{"cluster": "test"},
+		Namespace:      "test",
+	})
+	c.AddNodeGroup("bee", NodeGroupOptions{
+		Image:              "ethersphere/bee:0.5.3",
+		PersistenceEnabled: false,
+		ResourcesLimitCPU:  "1",
+	})
+	g, err := c.NodeGroup("bee")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.AddNode("bee-0", NodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddNode("bee-1", NodeOptions{GroupOptions: &NodeGroupOptions{
+		Image:              "ethersphere/bee:0.6.0",
+		PersistenceEnabled: true,
+		ResourcesLimitCPU:  "2",
+		Labels:             map[string]string{"node": "bee-1"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		node        string
+		image       string
+		persistence bool
+		cpu         string
+		labels      map[string]string
+	}{
+		{
+			node:   "bee-0",
+			image:  "ethersphere/bee:0.5.3",
+			cpu:    "1",
+			labels: map[string]string{"cluster": "test"},
+		},
+		{
+			node:        "bee-1",
+			image:       "ethersphere/bee:0.6.0",
+			persistence: true,
+			cpu:         "2",
+			labels:      map[string]string{"cluster": "test", "node": "bee-1"},
+		},
+	} {
+		t.Run(tc.node, func(t *testing.T) {
+			n, err := g.Node(tc.node)
+			if err != nil {
+				t.Fatal(err)
+			}
+			o := g.nodeOptions(n)
+			if o.Image != tc.image {
+				t.Errorf("got image %s, want %s", o.Image, tc.image)
+			}
+			if o.PersistenceEnabled != tc.persistence {
+				t.Errorf("got persistence %t, want %t", o.PersistenceEnabled, tc.persistence)
+			}
+			if o.ResourcesLimitCPU != tc.cpu {
+				t.Errorf("got cpu limit %s, want %s", o.ResourcesLimitCPU, tc.cpu)
+			}
+			if len(o.Labels) != len(tc.labels) {
+				t.Errorf("got labels %v, want %v", o.Labels, tc.labels)
+			}
+			for k, v := range tc.labels {
+				if o.Labels[k] != v {
+					t.Errorf("got labels %v, want %v", o.Labels, tc.labels)
+				}
+			}
+
+			image, err := g.NodeImage(tc.node)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if image != tc.image {
+				t.Errorf("got node image %s, want %s", image, tc.image)
+			}
+		})
+	}
+}
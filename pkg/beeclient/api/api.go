@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/ethersphere/beekeeper"
@@ -188,6 +189,10 @@ func (c *Client) requestWithHeader(ctx context.Context, method, path string, hea
 
 	req.Header = header
 	req.Header.Add("Accept", contentType)
+	// streamed bodies are sent with their length instead of chunked
+	if l, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64); err == nil && req.ContentLength == 0 {
+		req.ContentLength = l
+	}
 
 	r, err := c.httpClient.Do(req)
 	if err != nil {
@@ -272,6 +277,7 @@ func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
 
 type UploadOptions struct {
 	Pin     bool
+	Encrypt bool
 	Tag     uint32
 	BatchID string
 }
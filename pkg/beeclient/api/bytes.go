@@ -29,6 +29,9 @@ func (b *BytesService) Upload(ctx context.Context, data io.Reader, o UploadOptio
 	if o.Pin {
 		h.Add("Swarm-Pin", "true")
 	}
+	if o.Encrypt {
+		h.Add("Swarm-Encrypt", "true")
+	}
 	if o.Tag != 0 {
 		h.Add("Swarm-Tag", strconv.FormatUint(uint64(o.Tag), 10))
 	}
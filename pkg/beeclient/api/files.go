@@ -31,6 +31,9 @@ func (f *FilesService) Upload(ctx context.Context, name string, data io.Reader,
 	if o.Pin {
 		header.Set("Swarm-Pin", "true")
 	}
+	if o.Encrypt {
+		header.Set("Swarm-Encrypt", "true")
+	}
 	if o.Tag != 0 {
 		header.Set("Swarm-Tag", strconv.FormatUint(uint64(o.Tag), 10))
 	}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/ethersphere/bee/pkg/swarm"
@@ -34,16 +35,20 @@ func (ps *PinningService) UnpinRootHash(ctx context.Context, ref swarm.Address)
 }
 
 // GetPinnedRootHash determines if the root hash of
-// given reference is pinned by returning its reference.
+// given reference is pinned by returning its reference,
+// zero address is returned if it is not pinned.
 func (ps *PinningService) GetPinnedRootHash(ctx context.Context, ref swarm.Address) (swarm.Address, error) {
 	res := struct {
 		Reference swarm.Address `json:"reference"`
 	}{}
 	err := ps.client.requestJSON(ctx, http.MethodGet, pinsPath(ref.String()), nil, &res)
-	if err != nil {
+	if IsHTTPStatusErrorCode(err, http.StatusNotFound) {
 		return swarm.ZeroAddress, nil
 	}
-	return res.Reference, err
+	if err != nil {
+		return swarm.ZeroAddress, err
+	}
+	return res.Reference, nil
 }
 
 // GetPins returns all references of pinned root hashes.
@@ -53,7 +58,91 @@ func (ps *PinningService) GetPins(ctx context.Context) ([]swarm.Address, error)
 	}{}
 	err := ps.client.requestJSON(ctx, http.MethodGet, pinsBasePath, nil, &res)
 	if err != nil {
-		return nil, nil
+		return nil, err
+	}
+	return res.References, nil
+}
+
+// Endpoints below are pinning routes of Bee v0.5.3 API (pkg/api/router.go),
+// which pins chunks one by one, keeping a pin counter for every chunk.
+
+// pinResponse represents response of pin and unpin requests
+type pinResponse struct {
+	Message string `json:"message,omitempty"`
+	Code    int    `json:"code,omitempty"`
+}
+
+// PinBytes pins all chunks of the data (POST /pin/bytes/{address}).
+func (ps *PinningService) PinBytes(ctx context.Context, ref swarm.Address) error {
+	return ps.client.requestJSON(ctx, http.MethodPost, "/pin/bytes/"+ref.String(), nil, &pinResponse{})
+}
+
+// UnpinBytes unpins all chunks of the data (DELETE /pin/bytes/{address}).
+func (ps *PinningService) UnpinBytes(ctx context.Context, ref swarm.Address) error {
+	return ps.client.requestJSON(ctx, http.MethodDelete, "/pin/bytes/"+ref.String(), nil, &pinResponse{})
+}
+
+// PinFile pins all chunks of the file (POST /pin/files/{address}).
+func (ps *PinningService) PinFile(ctx context.Context, ref swarm.Address) error {
+	return ps.client.requestJSON(ctx, http.MethodPost, "/pin/files/"+ref.String(), nil, &pinResponse{})
+}
+
+// UnpinFile unpins all chunks of the file (DELETE /pin/files/{address}).
+func (ps *PinningService) UnpinFile(ctx context.Context, ref swarm.Address) error {
+	return ps.client.requestJSON(ctx, http.MethodDelete, "/pin/files/"+ref.String(), nil, &pinResponse{})
+}
+
+// PinCollection pins all chunks of the manifest and the files it references
+// (POST /pin/bzz/{address}).
+func (ps *PinningService) PinCollection(ctx context.Context, ref swarm.Address) error {
+	return ps.client.requestJSON(ctx, http.MethodPost, "/pin/bzz/"+ref.String(), nil, &pinResponse{})
+}
+
+// UnpinCollection unpins all chunks of the manifest and the files it
+// references (DELETE /pin/bzz/{address}).
+func (ps *PinningService) UnpinCollection(ctx context.Context, ref swarm.Address) error {
+	return ps.client.requestJSON(ctx, http.MethodDelete, "/pin/bzz/"+ref.String(), nil, &pinResponse{})
+}
+
+// PinChunk increments pin counter of the chunk (POST /pin/chunks/{address}).
+func (ps *PinningService) PinChunk(ctx context.Context, a swarm.Address) error {
+	return ps.client.requestJSON(ctx, http.MethodPost, "/pin/chunks/"+a.String(), nil, &pinResponse{})
+}
+
+// UnpinChunk decrements pin counter of the chunk, the chunk is unpinned when
+// the counter reaches zero (DELETE /pin/chunks/{address}).
+func (ps *PinningService) UnpinChunk(ctx context.Context, a swarm.Address) error {
+	return ps.client.requestJSON(ctx, http.MethodDelete, "/pin/chunks/"+a.String(), nil, &pinResponse{})
+}
+
+// PinnedChunk represents pinned chunk and its pin counter
+type PinnedChunk struct {
+	Address    swarm.Address `json:"address"`
+	PinCounter uint64        `json:"pinCounter"`
+}
+
+// GetPinnedChunk returns pin counter of the chunk, zero counter is returned
+// if the chunk is not pinned (GET /pin/chunks/{address}).
+func (ps *PinningService) GetPinnedChunk(ctx context.Context, a swarm.Address) (PinnedChunk, error) {
+	var res PinnedChunk
+	err := ps.client.requestJSON(ctx, http.MethodGet, "/pin/chunks/"+a.String(), nil, &res)
+	if IsHTTPStatusErrorCode(err, http.StatusNotFound) {
+		return PinnedChunk{Address: a}, nil
+	}
+	if err != nil {
+		return PinnedChunk{}, err
+	}
+	return res, nil
+}
+
+// GetPinnedChunks returns page of pinned chunks (GET /pin/chunks).
+func (ps *PinningService) GetPinnedChunks(ctx context.Context, offset, limit int) ([]PinnedChunk, error) {
+	res := struct {
+		Chunks []PinnedChunk `json:"chunks"`
+	}{}
+	err := ps.client.requestJSON(ctx, http.MethodGet, fmt.Sprintf("/pin/chunks?offset=%d&limit=%d", offset, limit), nil, &res)
+	if err != nil {
+		return nil, err
 	}
-	return res.References, err
+	return res.Chunks, nil
 }
@@ -7,7 +7,8 @@ import (
 	"github.com/ethersphere/bee/pkg/swarm"
 )
 
-// StewardshipService represents Bee's Stewardship service.
+// StewardshipService represents Bee's Stewardship service, Bee v0.5.3 doesn't
+// route it.
 type StewardshipService service
 
 // stewardshipBasePath is the stewardship API base path for http requests.
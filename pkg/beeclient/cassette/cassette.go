@@ -0,0 +1,91 @@
+// Package cassette records requests and responses of Bee API and debug API
+// clients to a cassette file and replays them, so that checks can be run
+// again offline against traffic captured from a cluster.
+package cassette
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// APIs of the Bee node whose requests are recorded
+const (
+	API      = "api"
+	DebugAPI = "debugapi"
+)
+
+// ErrNotRecorded is returned by replay transport for requests that are not
+// in the cassette
+var ErrNotRecorded = errors.New("request not recorded")
+
+// Transporter returns transport that records or replays requests made to the
+// API of the node
+type Transporter interface {
+	Transport(k Key, base http.RoundTripper) http.RoundTripper
+}
+
+// Key identifies client whose requests are recorded
+type Key struct {
+	Node string `json:"node"`
+	API  string `json:"api"`
+}
+
+// Interaction represents recorded request and its response
+type Interaction struct {
+	Key
+	Method        string `json:"method"`
+	URL           string `json:"url"` // request URI, without scheme and host
+	RequestDigest string `json:"requestDigest,omitempty"`
+	RequestSize   int64  `json:"requestSize,omitempty"`
+	RequestBody   []byte `json:"requestBody,omitempty"`
+	// Error is set if the request failed without response
+	Error      string        `json:"error,omitempty"`
+	StatusCode int           `json:"statusCode,omitempty"`
+	Header     http.Header   `json:"header,omitempty"`
+	BodyDigest string        `json:"bodyDigest,omitempty"`
+	BodySize   int64         `json:"bodySize"`
+	Body       []byte        `json:"body,omitempty"`
+	Truncated  bool          `json:"truncated,omitempty"` // set if body was larger than the limit and only its digest is recorded
+	Start      time.Time     `json:"start"`
+	Duration   time.Duration `json:"duration"` // time until response headers were received
+}
+
+// match returns key the interaction is replayed by
+func (i *Interaction) match() string {
+	return fmt.Sprintf("%s %s %s %s %s", i.Node, i.API, i.Method, i.URL, i.RequestDigest)
+}
+
+// Read reads interactions from the cassette file
+func Read(path string) (interactions []Interaction, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	d := json.NewDecoder(bufio.NewReader(f))
+	for {
+		var i Interaction
+		if err := d.Decode(&i); err != nil {
+			if errors.Is(err, io.EOF) {
+				return interactions, nil
+			}
+			return nil, fmt.Errorf("read cassette %s: %w", path, err)
+		}
+		interactions = append(interactions, i)
+	}
+}
+
+// digest returns hex encoded SHA-256 digest of the data
+func digest(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,172 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	large := strings.Repeat("x", 100)
+
+	var mu sync.Mutex
+	count := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/counter":
+			mu.Lock()
+			count++
+			c := count
+			mu.Unlock()
+			fmt.Fprint(w, c)
+		case "/echo":
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("X-Echo", "yes")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write(body)
+		case "/large":
+			fmt.Fprint(w, large)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(path, Options{MaxBodySize: 32})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := Key{Node: "bee-0", API: API}
+	record := &http.Client{Transport: recorder.Transport(key, http.DefaultTransport)}
+
+	for _, want := range []string{"1", "2", "3"} {
+		if got := get(t, record, server.URL+"/counter"); got != want {
+			t.Fatalf("recording: got counter %s, want %s", got, want)
+		}
+	}
+	for _, body := range []string{"a", "b"} {
+		if got := post(t, record, server.URL+"/echo", strings.NewReader(body)); got != body {
+			t.Fatalf("recording: got echo %s, want %s", got, body)
+		}
+	}
+	// body that can't be read again is digested while it is sent
+	if got := post(t, record, server.URL+"/echo", ioutil.NopCloser(strings.NewReader("streamed"))); got != "streamed" {
+		t.Fatalf("recording: got echo %s, want streamed", got)
+	}
+	// body larger than the limit is returned whole, but only its digest is recorded
+	if got := get(t, record, server.URL+"/large"); got != large {
+		t.Fatalf("recording: got %d bytes, want %d", len(got), len(large))
+	}
+
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	interactions, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(interactions) != 7 {
+		t.Fatalf("got %d interactions, want 7", len(interactions))
+	}
+	last := interactions[len(interactions)-1]
+	if !last.Truncated || last.Body != nil || last.BodySize != int64(len(large)) || last.BodyDigest != digest([]byte(large)) {
+		t.Fatalf("got truncated %t, body %q, size %d and digest %s of large body", last.Truncated, last.Body, last.BodySize, last.BodyDigest)
+	}
+
+	player := NewPlayer(interactions)
+	play := &http.Client{Transport: player.Transport(key, nil)}
+
+	t.Run("repeated requests in order", func(t *testing.T) {
+		// the last response is replayed once all are replayed
+		for _, want := range []string{"1", "2", "3", "3", "3"} {
+			if got := get(t, play, server.URL+"/counter"); got != want {
+				t.Fatalf("got counter %s, want %s", got, want)
+			}
+		}
+	})
+
+	t.Run("request body digest", func(t *testing.T) {
+		for _, body := range []string{"b", "streamed", "a"} {
+			resp, err := play.Post(server.URL+"/echo", "text/plain", strings.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := readBody(t, resp)
+			if got != body {
+				t.Fatalf("got echo %s, want %s", got, body)
+			}
+			if resp.StatusCode != http.StatusCreated || resp.Header.Get("X-Echo") != "yes" {
+				t.Fatalf("got status %d and header %v", resp.StatusCode, resp.Header)
+			}
+		}
+
+		if _, err := play.Post(server.URL+"/echo", "text/plain", strings.NewReader("c")); !errors.Is(err, ErrNotRecorded) {
+			t.Fatalf("got error %v for body not recorded, want %v", err, ErrNotRecorded)
+		}
+	})
+
+	t.Run("truncated body", func(t *testing.T) {
+		_, err := play.Get(server.URL + "/large")
+		if !errors.Is(err, ErrNotRecorded) || !strings.Contains(err.Error(), "only digest") {
+			t.Fatalf("got error %v, want %v of digest only", err, ErrNotRecorded)
+		}
+	})
+
+	t.Run("other client", func(t *testing.T) {
+		other := &http.Client{Transport: player.Transport(Key{Node: "bee-1", API: API}, nil)}
+		if _, err := other.Get(server.URL + "/counter"); !errors.Is(err, ErrNotRecorded) {
+			t.Fatalf("got error %v, want %v", err, ErrNotRecorded)
+		}
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if count != 3 {
+		t.Fatalf("got %d requests to the server, want 3 recorded", count)
+	}
+}
+
+func get(t *testing.T, c *http.Client, url string) string {
+	t.Helper()
+
+	resp, err := c.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return readBody(t, resp)
+}
+
+func post(t *testing.T, c *http.Client, url string, body io.Reader) string {
+	t.Helper()
+
+	resp, err := c.Post(url, "text/plain", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return readBody(t, resp)
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(bytes.TrimSpace(b))
+}
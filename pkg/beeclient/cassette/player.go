@@ -0,0 +1,103 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// Player serves recorded responses instead of sending requests
+type Player struct {
+	mu sync.Mutex
+	// queues are interactions not yet replayed by the request they match
+	queues map[string][]*Interaction
+	// last are the last replayed interactions by the request they match
+	last map[string]*Interaction
+}
+
+// compile check whether Player implements interface
+var _ Transporter = (*Player)(nil)
+
+// NewPlayer returns player of the interactions
+func NewPlayer(interactions []Interaction) *Player {
+	p := &Player{
+		queues: make(map[string][]*Interaction),
+		last:   make(map[string]*Interaction),
+	}
+	for i := range interactions {
+		m := interactions[i].match()
+		p.queues[m] = append(p.queues[m], &interactions[i])
+	}
+
+	return p
+}
+
+// Transport returns transport that replays responses recorded for the client,
+// base transport is not used
+func (p *Player) Transport(k Key, _ http.RoundTripper) http.RoundTripper {
+	return &playTransport{player: p, key: k}
+}
+
+// next returns the next interaction matching the request, responses are
+// replayed in the order they were recorded and the last one is replayed again
+// once all are replayed, so that polling the node ends as it did
+func (p *Player) next(r *Interaction) (*Interaction, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	m := r.match()
+	if q := p.queues[m]; len(q) > 0 {
+		p.queues[m], p.last[m] = q[1:], q[0]
+		return q[0], true
+	}
+	i, ok := p.last[m]
+	return i, ok
+}
+
+type playTransport struct {
+	player *Player
+	key    Key
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *playTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := &Interaction{
+		Key:    t.key,
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		r.RequestDigest, _, _, err = readDigest(req.Body, 0)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	i, ok := t.player.next(r)
+	if !ok {
+		return nil, fmt.Errorf("%s %s %s %s: %w", r.Node, r.API, r.Method, r.URL, ErrNotRecorded)
+	}
+	if len(i.Error) > 0 {
+		return nil, errors.New(i.Error)
+	}
+	if i.Truncated {
+		return nil, fmt.Errorf("%s %s %s %s: only digest of %d bytes response body recorded: %w", r.Node, r.API, r.Method, r.URL, i.BodySize, ErrNotRecorded)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.StatusCode, http.StatusText(i.StatusCode)),
+		StatusCode:    i.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        i.Header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(i.Body)),
+		ContentLength: int64(len(i.Body)),
+		Request:       req,
+	}, nil
+}
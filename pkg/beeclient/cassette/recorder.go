@@ -0,0 +1,274 @@
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Options represents recorder options
+type Options struct {
+	// MaxBodySize is the size of the largest request and response body that
+	// is recorded, only digest and size of larger bodies are recorded
+	MaxBodySize int64
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		MaxBodySize: 16 * 1024 * 1024, // 16mb
+	}
+}
+
+// Recorder writes interactions to the cassette file as they complete
+type Recorder struct {
+	o Options
+
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+	err error
+}
+
+// compile check whether Recorder implements interface
+var _ Transporter = (*Recorder)(nil)
+
+// NewRecorder creates the cassette file and returns recorder that writes to it
+func NewRecorder(path string, o Options) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create cassette %s: %w", path, err)
+	}
+
+	return &Recorder{
+		o:   o,
+		f:   f,
+		enc: json.NewEncoder(f),
+	}, nil
+}
+
+// Transport returns transport that records requests sent through the base
+// transport
+func (r *Recorder) Transport(k Key, base http.RoundTripper) http.RoundTripper {
+	return &recordTransport{recorder: r, key: k, base: base}
+}
+
+// Close closes the cassette file and returns the first error that occurred
+// while writing interactions
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.f.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	return r.err
+}
+
+// write appends the interaction to the cassette file, the first error is kept
+// and returned on Close so that recording doesn't fail requests
+func (r *Recorder) write(i *Interaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return
+	}
+	if err := r.enc.Encode(i); err != nil {
+		r.err = fmt.Errorf("write cassette %s: %w", r.f.Name(), err)
+	}
+}
+
+type recordTransport struct {
+	recorder *Recorder
+	key      Key
+	base     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	limit := t.recorder.o.MaxBodySize
+	i := &Interaction{
+		Key:    t.key,
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+		Start:  time.Now(),
+	}
+
+	var sent *digestBody
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			i.RequestDigest, i.RequestSize, i.RequestBody, err = readDigest(body, limit)
+			body.Close()
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// body that can't be read again is digested while it is sent, so
+			// that large uploads are not buffered
+			sent = newDigestBody(req.Body, limit)
+			req = req.Clone(req.Context())
+			req.Body = sent
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	i.Duration = time.Since(i.Start)
+	if sent != nil {
+		i.RequestDigest, i.RequestSize = hex.EncodeToString(sent.hash.Sum(nil)), sent.size
+		if sent.size <= limit {
+			i.RequestBody = sent.buf.Bytes()
+		}
+	}
+	if err != nil {
+		i.Error = err.Error()
+		t.recorder.write(i)
+		return nil, err
+	}
+	i.StatusCode = resp.StatusCode
+	i.Header = resp.Header.Clone()
+
+	// bodies within the limit are recorded before they are returned
+	prefix, err := ioutil.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if int64(len(prefix)) <= limit {
+		i.Body, i.BodySize, i.BodyDigest = prefix, int64(len(prefix)), digest(prefix)
+		t.recorder.write(i)
+		resp.Body = &readCloser{Reader: bytes.NewReader(prefix), Closer: resp.Body}
+		return resp, nil
+	}
+
+	// larger bodies are streamed and only their digest is recorded once they
+	// are read or closed
+	i.Truncated = true
+	resp.Body = &recordBody{
+		Reader:      io.MultiReader(bytes.NewReader(prefix), resp.Body),
+		Closer:      resp.Body,
+		recorder:    t.recorder,
+		interaction: i,
+		hash:        sha256.New(),
+	}
+	return resp, nil
+}
+
+// readDigest reads the body and returns its digest, size and the body if it
+// is not larger than the limit
+func readDigest(r io.Reader, limit int64) (sum string, size int64, body []byte, err error) {
+	h := sha256.New()
+	buf := new(bytes.Buffer)
+	size, err = io.Copy(io.MultiWriter(h, &limitedWriter{w: buf, n: limit + 1}), r)
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if size <= limit {
+		body = buf.Bytes()
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, body, nil
+}
+
+// limitedWriter writes at most n bytes to w and discards the rest
+type limitedWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.n <= 0 {
+		return len(p), nil
+	}
+	q := p
+	if int64(len(q)) > l.n {
+		q = q[:l.n]
+	}
+	n, err := l.w.Write(q)
+	l.n -= int64(n)
+	if err != nil {
+		return n, err
+	}
+	return len(p), nil
+}
+
+// digestBody computes digest of the request body and keeps its beginning as
+// it is sent
+type digestBody struct {
+	io.ReadCloser
+	hash hash.Hash
+	buf  *bytes.Buffer
+	w    io.Writer
+	size int64
+}
+
+// newDigestBody returns digestBody that keeps at most limit+1 bytes of the body
+func newDigestBody(body io.ReadCloser, limit int64) *digestBody {
+	h, buf := sha256.New(), new(bytes.Buffer)
+	return &digestBody{
+		ReadCloser: body,
+		hash:       h,
+		buf:        buf,
+		w:          io.MultiWriter(h, &limitedWriter{w: buf, n: limit + 1}),
+	}
+}
+
+func (b *digestBody) Read(p []byte) (n int, err error) {
+	n, err = b.ReadCloser.Read(p)
+	_, _ = b.w.Write(p[:n])
+	b.size += int64(n)
+	return
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// recordBody computes digest of the body as it is read and records the
+// interaction when the body is read to the end or closed
+type recordBody struct {
+	io.Reader
+	io.Closer
+	recorder    *Recorder
+	interaction *Interaction
+	hash        hash.Hash
+	size        int64
+	once        sync.Once
+}
+
+func (b *recordBody) Read(p []byte) (n int, err error) {
+	n, err = b.Reader.Read(p)
+	b.hash.Write(p[:n])
+	b.size += int64(n)
+	if err == io.EOF {
+		b.record()
+	}
+	return
+}
+
+func (b *recordBody) Close() error {
+	b.record()
+	return b.Closer.Close()
+}
+
+// record records the interaction with digest and size of the body read so far
+func (b *recordBody) record() {
+	b.once.Do(func() {
+		b.interaction.BodyDigest = hex.EncodeToString(b.hash.Sum(nil))
+		b.interaction.BodySize = b.size
+		b.recorder.write(b.interaction)
+	})
+}
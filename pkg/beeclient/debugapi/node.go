@@ -2,6 +2,7 @@ package debugapi
 
 import (
 	"context"
+	"math/big"
 	"net/http"
 	"time"
 
@@ -78,9 +79,13 @@ func (n *NodeService) RemoveChunk(ctx context.Context, a swarm.Address) error {
 	return n.client.requestJSON(ctx, http.MethodDelete, "/chunks/"+a.String(), nil, &resp)
 }
 
-// Health represents node's health
+// Health represents node's health, Bee v0.5.3 /health (pkg/debugapi/status.go)
+// returns only status and version, API versions are reported by later versions
 type Health struct {
-	Status string `json:"status"`
+	Status          string `json:"status"`
+	Version         string `json:"version"`
+	APIVersion      string `json:"apiVersion"`
+	DebugAPIVersion string `json:"debugApiVersion"`
 }
 
 // Health returns node's health
@@ -105,6 +110,34 @@ func (n *NodeService) Peers(ctx context.Context) (resp Peers, err error) {
 	return
 }
 
+// BlocklistedPeer represents peer the node blocked, Bee v0.5.3 lists only
+// addresses of blocked peers, without durations of blocks
+type BlocklistedPeer struct {
+	Address swarm.Address `json:"address"`
+}
+
+// BlocklistedPeers represents node's blocklisted peers
+type BlocklistedPeers struct {
+	Peers []BlocklistedPeer `json:"peers"`
+}
+
+// Blocklist returns node's blocklisted peers (GET /blocklist in Bee v0.5.3)
+func (n *NodeService) Blocklist(ctx context.Context) (resp BlocklistedPeers, err error) {
+	err = n.client.requestJSON(ctx, http.MethodGet, "/blocklist", nil, &resp)
+	return
+}
+
+// DisconnectPeer disconnects the peer without blocking it (DELETE
+// /peers/{address} in Bee v0.5.3)
+func (n *NodeService) DisconnectPeer(ctx context.Context, a swarm.Address) error {
+	resp := struct {
+		Message string `json:"message,omitempty"`
+		Code    int    `json:"code,omitempty"`
+	}{}
+
+	return n.client.requestJSON(ctx, http.MethodDelete, "/peers/"+a.String(), nil, &resp)
+}
+
 // Readiness represents node's readiness
 type Readiness struct {
 	Status string `json:"status"`
@@ -186,6 +219,29 @@ func (n *NodeService) ChequebookBalance(ctx context.Context) (resp ChequebookBal
 	return
 }
 
+// ChequebookAddressResponse represents node's chequebook address
+type ChequebookAddressResponse struct {
+	ChequebookAddress string `json:"chequebookAddress"`
+}
+
+// ChequebookAddress returns node's chequebook address
+func (n *NodeService) ChequebookAddress(ctx context.Context) (resp ChequebookAddressResponse, err error) {
+	err = n.client.request(ctx, http.MethodGet, "/chequebook/address", nil, &resp)
+	return
+}
+
+// ChequebookDeposit deposits amount from the node's wallet to the chequebook
+func (n *NodeService) ChequebookDeposit(ctx context.Context, amount *big.Int) (resp TransactionHashResponse, err error) {
+	err = n.client.request(ctx, http.MethodPost, "/chequebook/deposit?amount="+amount.String(), nil, &resp)
+	return
+}
+
+// ChequebookWithdraw withdraws amount from the chequebook to the node's wallet
+func (n *NodeService) ChequebookWithdraw(ctx context.Context, amount *big.Int) (resp TransactionHashResponse, err error) {
+	err = n.client.request(ctx, http.MethodPost, "/chequebook/withdraw?amount="+amount.String(), nil, &resp)
+	return
+}
+
 // Topology represents Kademlia topology
 type Topology struct {
 	BaseAddr       swarm.Address  `json:"baseAddr"`
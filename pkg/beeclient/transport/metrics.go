@@ -0,0 +1,188 @@
+package transport
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	requestsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "beekeeper",
+			Subsystem: "api_client",
+			Name:      "requests_total",
+			Help:      "Number of requests sent to Bee API and debug API.",
+		},
+		[]string{"host", "endpoint", "method"},
+	)
+	errorsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "beekeeper",
+			Subsystem: "api_client",
+			Name:      "errors_total",
+			Help:      "Number of failed requests by response status code, code is \"error\" if request failed without response.",
+		},
+		[]string{"host", "endpoint", "method", "code"},
+	)
+	durationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "beekeeper",
+			Subsystem: "api_client",
+			Name:      "request_duration_seconds",
+			Help:      "Time until response headers are received, including retries.",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"host", "endpoint", "method"},
+	)
+	sentBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "beekeeper",
+			Subsystem: "api_client",
+			Name:      "sent_bytes_total",
+			Help:      "Number of request body bytes sent, including retries.",
+		},
+		[]string{"host", "endpoint", "method"},
+	)
+	receivedBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "beekeeper",
+			Subsystem: "api_client",
+			Name:      "received_bytes_total",
+			Help:      "Number of response body bytes received.",
+		},
+		[]string{"host", "endpoint", "method"},
+	)
+)
+
+// Collectors returns metrics of requests sent through all transports, they
+// are meant to be added to the metrics pusher
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		requestsCounter,
+		errorsCounter,
+		durationHistogram,
+		sentBytesCounter,
+		receivedBytesCounter,
+	}
+}
+
+// errorCode returns value of the code label of the failed request
+func errorCode(statusCode int) string {
+	if statusCode == 0 {
+		return "error"
+	}
+	return strconv.Itoa(statusCode)
+}
+
+// routes are names of Bee API and debug API endpoints, path segments after
+// the name are parameters, e.g. addresses, pss topics or paths in collections
+var routes = [][]string{
+	{"addresses"},
+	{"balances"},
+	{"blocklist"},
+	{"bytes"},
+	{"bzz"},
+	{"chequebook", "address"},
+	{"chequebook", "balance"},
+	{"chequebook", "cashout"},
+	{"chequebook", "cheque"},
+	{"chequebook", "deposit"},
+	{"chequebook", "withdraw"},
+	{"chunks"},
+	{"connect"},
+	{"consumed"},
+	{"dirs"},
+	{"feeds"},
+	{"files"},
+	{"health"},
+	{"peers"},
+	{"pin", "bytes"},
+	{"pin", "bzz"},
+	{"pin", "chunks"},
+	{"pin", "files"},
+	{"pingpong"},
+	{"pins"},
+	{"pss", "send"},
+	{"pss", "subscribe"},
+	{"readiness"},
+	{"reservestate"},
+	{"settlements"},
+	{"soc"},
+	{"stamps"},
+	{"stewardship"},
+	{"tags"},
+	{"topology"},
+	{"welcome-message"},
+}
+
+// endpoint returns request path with all segments after the endpoint name
+// replaced by placeholder to keep metrics' cardinality low, paths of unknown
+// endpoints are replaced completely
+func endpoint(path string) string {
+	path = strings.Trim(path, "/")
+	if len(path) == 0 {
+		return "/"
+	}
+
+	segments := strings.Split(path, "/")
+	var version, names int
+	// API version, e.g. v1, precedes the endpoint name
+	if isVersion(segments[0]) {
+		version = 1
+	}
+	for _, r := range routes {
+		if hasPrefix(segments[version:], r) {
+			names = version + len(r)
+			break
+		}
+	}
+	if names == 0 {
+		return "/:param"
+	}
+
+	for i := names; i < len(segments); i++ {
+		segments[i] = ":param"
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// hasPrefix reports whether path segments start with the route name
+func hasPrefix(segments, route []string) bool {
+	if len(segments) < len(route) {
+		return false
+	}
+	for i := range route {
+		if segments[i] != route[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// isVersion reports whether path segment is API version like v1
+func isVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// countingBody adds number of bytes read from the body to the counter
+type countingBody struct {
+	io.ReadCloser
+	counter prometheus.Counter
+}
+
+func (b *countingBody) Read(p []byte) (n int, err error) {
+	n, err = b.ReadCloser.Read(p)
+	b.counter.Add(float64(n))
+	return
+}
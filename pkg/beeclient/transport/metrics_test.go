@@ -0,0 +1,57 @@
+package transport
+
+import "testing"
+
+func TestEndpoint(t *testing.T) {
+	for _, tc := range []struct {
+		path string
+		want string
+	}{
+		{path: "", want: "/"},
+		{path: "/", want: "/"},
+		{path: "/health", want: "/health"},
+		{path: "/addresses/", want: "/addresses"},
+		{path: "/chequebook/balance", want: "/chequebook/balance"},
+		{path: "/chequebook/cashout/8f3bc9c5a2b4e6f10b3c0f2a1d9e7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d", want: "/chequebook/cashout/:param"},
+		{path: "/v1/bytes", want: "/v1/bytes"},
+		{path: "/v1/bytes/8f3bc9c5a2b4e6f10b3c0f2a1d9e7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d", want: "/v1/bytes/:param"},
+		{path: "/bzz/8f3bc9c5a2b4e6f10b3c0f2a1d9e7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d/docs/index", want: "/bzz/:param/:param/:param"},
+		{path: "/pss/send/topic/ab", want: "/pss/send/:param/:param"},
+		{path: "/pss/subscribe/lowercase-topic", want: "/pss/subscribe/:param"},
+		{path: "/pin/chunks", want: "/pin/chunks"},
+		{path: "/pin/chunks/8f3bc9c5", want: "/pin/chunks/:param"},
+		{path: "/stamps/1000/16", want: "/stamps/:param/:param"},
+		{path: "/tags/12", want: "/tags/:param"},
+		{path: "/unknown/path", want: "/:param"},
+		{path: "/v1", want: "/:param"},
+		{path: "/v1/unknown", want: "/:param"},
+		{path: "/pin", want: "/:param"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			if got := endpoint(tc.path); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsVersion(t *testing.T) {
+	for _, tc := range []struct {
+		segment string
+		want    bool
+	}{
+		{segment: "v1", want: true},
+		{segment: "v10", want: true},
+		{segment: "v", want: false},
+		{segment: "vx", want: false},
+		{segment: "1", want: false},
+		{segment: "bytes", want: false},
+		{segment: "", want: false},
+	} {
+		t.Run(tc.segment, func(t *testing.T) {
+			if got := isVersion(tc.segment); got != tc.want {
+				t.Fatalf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+// Package transport provides HTTP transport shared by Bee API and debug API
+// clients that retries failed idempotent requests, limits connections, stops
+// sending requests to hosts that are down and records metrics and traces of
+// the requests.
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the tracer requests' spans are started with
+const tracerName = "github.com/ethersphere/beekeeper/pkg/beeclient/transport"
+
+// ErrCircuitOpen is returned when requests to the host are not sent because
+// too many requests to it failed recently
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Options represents transport policy
+type Options struct {
+	// Retries is the number of times idempotent request is retried after
+	// connection error or 5xx response
+	Retries int
+	// BackoffMin and BackoffMax bound jittered exponential backoff between retries
+	BackoffMin time.Duration
+	BackoffMax time.Duration
+	// Timeout is request timeout, including retries, used if request's
+	// context has no deadline
+	Timeout time.Duration
+	// MaxConnsPerHost limits number of connections to the host, 0 means no limit
+	MaxConnsPerHost     int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	// BreakerThreshold is the number of consecutive failed requests after
+	// which requests to the host fail fast, 0 disables circuit breaker
+	BreakerThreshold int
+	// BreakerCooldown is time after which single request is let through to
+	// probe whether the host recovered
+	BreakerCooldown time.Duration
+	TLSClientConfig *tls.Config
+	// TracerProvider provides tracer that starts span for every request,
+	// global tracer provider is used if not set
+	TracerProvider trace.TracerProvider
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		Retries:             3,
+		BackoffMin:          250 * time.Millisecond,
+		BackoffMax:          5 * time.Second,
+		Timeout:             0,
+		MaxConnsPerHost:     0,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+		BreakerThreshold:    10,
+		BreakerCooldown:     30 * time.Second,
+	}
+}
+
+// Transport is http.RoundTripper that applies the policy to requests made
+// through the underlying transport
+type Transport struct {
+	base   http.RoundTripper
+	o      Options
+	tracer trace.Tracer
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+// New returns Transport with connection pool limits and TLS configuration
+// from the options
+func New(o Options) *Transport {
+	return Wrap(&http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		TLSClientConfig:     o.TLSClientConfig,
+		MaxConnsPerHost:     o.MaxConnsPerHost,
+		MaxIdleConnsPerHost: o.MaxIdleConnsPerHost,
+		IdleConnTimeout:     o.IdleConnTimeout,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}, o)
+}
+
+// Wrap returns Transport that sends requests through the base transport
+func Wrap(base http.RoundTripper, o Options) *Transport {
+	tp := o.TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+
+	return &Transport{
+		base:     base,
+		o:        o,
+		tracer:   tp.Tracer(tracerName),
+		breakers: make(map[string]*breaker),
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(r *http.Request) (resp *http.Response, err error) {
+	labels := []string{r.URL.Host, endpoint(r.URL.Path), r.Method}
+	start := time.Now()
+	requestsCounter.WithLabelValues(labels...).Inc()
+
+	ctx, span := t.tracer.Start(r.Context(), r.Method+" "+labels[1],
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String(r.Method),
+			semconv.HTTPURLKey.String(r.URL.String()),
+			semconv.NetPeerNameKey.String(r.URL.Host),
+		),
+	)
+	defer func() {
+		durationHistogram.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+		t.finish(span, labels, resp, err)
+		if resp != nil {
+			resp.Body = &countingBody{ReadCloser: resp.Body, counter: receivedBytesCounter.WithLabelValues(labels...)}
+		}
+	}()
+
+	cancel := context.CancelFunc(func() {})
+	if _, ok := ctx.Deadline(); !ok && t.o.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.o.Timeout)
+	}
+
+	b := t.breaker(r.URL.Host)
+	retries := 0
+	if isIdempotent(r) {
+		retries = t.o.Retries
+	}
+
+	if !b.allow() {
+		cancel()
+		return nil, fmt.Errorf("%s: %w", r.URL.Host, ErrCircuitOpen)
+	}
+
+	for attempt := 0; ; attempt++ {
+		req := r.WithContext(ctx)
+		if attempt > 0 && r.GetBody != nil {
+			if req.Body, err = r.GetBody(); err != nil {
+				cancel()
+				return nil, err
+			}
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			req.Body = &countingBody{ReadCloser: req.Body, counter: sentBytesCounter.WithLabelValues(labels...)}
+		}
+
+		resp, err = t.base.RoundTrip(req)
+		if ctx.Err() != nil {
+			// request was canceled, it says nothing about the host
+			b.release()
+			break
+		}
+
+		failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+		b.record(!failed)
+		// result of the last attempt is returned if circuit breaker opened
+		if !failed || attempt >= retries || !b.allow() {
+			break
+		}
+
+		if resp != nil {
+			span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", attempt+1), semconv.HTTPStatusCodeKey.Int(resp.StatusCode)))
+			drain(resp.Body)
+		} else {
+			span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", attempt+1), attribute.String("error", err.Error())))
+		}
+		select {
+		case <-time.After(t.backoff(attempt)):
+		case <-ctx.Done():
+			cancel()
+			return nil, ctx.Err()
+		}
+	}
+
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// timeout must not expire before the body is read
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// finish records failed request and ends its span
+func (t *Transport) finish(span trace.Span, labels []string, resp *http.Response, err error) {
+	defer span.End()
+
+	if err != nil {
+		errorsCounter.WithLabelValues(append(labels, errorCode(0))...).Inc()
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		errorsCounter.WithLabelValues(append(labels, errorCode(resp.StatusCode))...).Inc()
+		span.SetStatus(codes.Error, resp.Status)
+	}
+}
+
+// backoff returns jittered exponential backoff before the retry
+func (t *Transport) backoff(attempt int) time.Duration {
+	d := t.o.BackoffMin << uint(attempt)
+	if d <= 0 || d > t.o.BackoffMax {
+		d = t.o.BackoffMax
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// breaker returns circuit breaker of the host
+func (t *Transport) breaker(host string) *breaker {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b, ok := t.breakers[host]
+	if !ok {
+		b = &breaker{threshold: t.o.BreakerThreshold, cooldown: t.o.BreakerCooldown}
+		t.breakers[host] = b
+	}
+	return b
+}
+
+// breaker counts consecutive failed requests to the host and rejects requests
+// during cooldown once the threshold is reached
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// allow reports whether request can be sent, after cooldown only one probing
+// request is allowed until its result is recorded
+func (b *breaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || time.Now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record records result of the request
+func (b *breaker) record(ok bool) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if ok {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// release lets another request probe the host without recording result
+func (b *breaker) release() {
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent returns context that marks requests made with it as safe to
+// send again regardless of their method
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// isIdempotent reports whether the request can be safely sent again
+func isIdempotent(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		if v, _ := r.Context().Value(idempotentKey{}).(bool); !v {
+			return false
+		}
+	}
+	return r.Body == nil || r.Body == http.NoBody || r.GetBody != nil
+}
+
+// cancelBody cancels request's context when the body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// drain discards the rest of the body so that the connection can be reused
+func drain(r io.ReadCloser) {
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(r, 1<<16))
+	r.Close()
+}
@@ -0,0 +1,291 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIsIdempotent(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		method     string
+		body       func() *http.Request
+		idempotent bool
+		want       bool
+	}{
+		{name: "get", method: http.MethodGet, want: true},
+		{name: "head", method: http.MethodHead, want: true},
+		{name: "delete", method: http.MethodDelete, want: true},
+		{name: "put with replayable body", method: http.MethodPut, want: true},
+		{name: "post", method: http.MethodPost, want: false},
+		{name: "post marked idempotent", method: http.MethodPost, idempotent: true, want: true},
+		{name: "patch", method: http.MethodPatch, want: false},
+		{
+			name:   "put with body that can't be replayed",
+			method: http.MethodPut,
+			body: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPut, "http://localhost", ioutil.NopCloser(strings.NewReader("data")))
+				return r
+			},
+			want: false,
+		},
+		{
+			name:   "post marked idempotent with body that can't be replayed",
+			method: http.MethodPost,
+			body: func() *http.Request {
+				r, _ := http.NewRequest(http.MethodPost, "http://localhost", ioutil.NopCloser(strings.NewReader("data")))
+				return r
+			},
+			idempotent: true,
+			want:       false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var r *http.Request
+			if tc.body != nil {
+				r = tc.body()
+			} else {
+				var err error
+				if r, err = http.NewRequest(tc.method, "http://localhost", strings.NewReader("data")); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tc.idempotent {
+				r = r.WithContext(WithIdempotent(r.Context()))
+			}
+
+			if got := isIdempotent(r); got != tc.want {
+				t.Fatalf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		min, max time.Duration
+	}{
+		{name: "default", min: 250 * time.Millisecond, max: 5 * time.Second},
+		{name: "min above max", min: 10 * time.Second, max: 5 * time.Second},
+		{name: "no max", min: time.Second, max: 0},
+		{name: "no backoff", min: 0, max: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := Wrap(http.DefaultTransport, Options{BackoffMin: tc.min, BackoffMax: tc.max})
+
+			for attempt := 0; attempt < 70; attempt++ {
+				want := tc.min << uint(attempt)
+				if want <= 0 || want > tc.max {
+					want = tc.max
+				}
+
+				for i := 0; i < 10; i++ {
+					if got := tr.backoff(attempt); got < want/2 || got > want {
+						t.Fatalf("attempt %d: got backoff %s, want between %s and %s", attempt, got, want/2, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		method     string
+		idempotent bool
+		failures   int
+		wantCalls  int
+		wantStatus int
+	}{
+		{name: "get recovers", method: http.MethodGet, failures: 2, wantCalls: 3, wantStatus: http.StatusOK},
+		{name: "get exhausts retries", method: http.MethodGet, failures: 10, wantCalls: 4, wantStatus: http.StatusServiceUnavailable},
+		{name: "post is not retried", method: http.MethodPost, failures: 2, wantCalls: 1, wantStatus: http.StatusServiceUnavailable},
+		{name: "post marked idempotent", method: http.MethodPost, idempotent: true, failures: 2, wantCalls: 3, wantStatus: http.StatusOK},
+		{name: "put body is replayed", method: http.MethodPut, failures: 2, wantCalls: 3, wantStatus: http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			body := []byte("request body")
+
+			var mu sync.Mutex
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Error(err)
+				}
+				mu.Lock()
+				calls++
+				call := calls
+				mu.Unlock()
+
+				// every attempt has to send the whole body
+				if !bytes.Equal(got, body) {
+					t.Errorf("call %d: got body %q, want %q", call, got, body)
+				}
+				if call <= tc.failures {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				_, _ = w.Write(got)
+			}))
+			defer server.Close()
+
+			o := NewDefaultOptions()
+			o.BackoffMin, o.BackoffMax = time.Millisecond, 5*time.Millisecond
+			client := &http.Client{Transport: New(o)}
+
+			ctx := context.Background()
+			if tc.idempotent {
+				ctx = WithIdempotent(ctx)
+			}
+			r, err := http.NewRequestWithContext(ctx, tc.method, server.URL, bytes.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			drain(resp.Body)
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("got status %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if calls != tc.wantCalls {
+				t.Fatalf("got %d calls, want %d", calls, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	var mu sync.Mutex
+	status, calls := http.StatusInternalServerError, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	cooldown := 50 * time.Millisecond
+	tr := New(Options{Retries: 0, BreakerThreshold: 2, BreakerCooldown: cooldown})
+	client := &http.Client{Transport: tr}
+
+	get := func() (int, error) {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			return 0, err
+		}
+		drain(resp.Body)
+		return resp.StatusCode, nil
+	}
+
+	// threshold of failed requests opens the breaker
+	for i := 0; i < 2; i++ {
+		if code, err := get(); err != nil || code != http.StatusInternalServerError {
+			t.Fatalf("request %d: got status %d and error %v", i, code, err)
+		}
+	}
+	if _, err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v, want %v", err, ErrCircuitOpen)
+	}
+	mu.Lock()
+	if calls != 2 {
+		t.Fatalf("got %d calls with open breaker, want 2", calls)
+	}
+	mu.Unlock()
+
+	// after cooldown only one probing request is let through
+	time.Sleep(cooldown)
+	b := tr.breaker(strings.TrimPrefix(server.URL, "http://"))
+	if !b.allow() {
+		t.Fatal("probe not allowed after cooldown")
+	}
+	if b.allow() {
+		t.Fatal("second probe allowed")
+	}
+	b.release()
+
+	// failed probe opens the breaker again
+	if code, err := get(); err != nil || code != http.StatusInternalServerError {
+		t.Fatalf("probe: got status %d and error %v", code, err)
+	}
+	if _, err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("got error %v after failed probe, want %v", err, ErrCircuitOpen)
+	}
+
+	// successful probe closes the breaker
+	time.Sleep(cooldown)
+	mu.Lock()
+	status = http.StatusOK
+	mu.Unlock()
+	for i := 0; i < 3; i++ {
+		if code, err := get(); err != nil || code != http.StatusOK {
+			t.Fatalf("request %d after successful probe: got status %d and error %v", i, code, err)
+		}
+	}
+}
+
+func TestTimeoutBody(t *testing.T) {
+	body := strings.Repeat("data", 1<<16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	o := NewDefaultOptions()
+	o.Timeout = time.Minute
+	client := &http.Client{Transport: New(o)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	// body is read after the round trip returned, context created for the
+	// timeout must still be alive
+	got, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != body {
+		t.Fatalf("got %d bytes, want %d", len(got), len(body))
+	}
+
+	// timeout still applies to reading the body
+	o.Timeout = 50 * time.Millisecond
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("first"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer slow.Close()
+
+	client = &http.Client{Transport: New(o)}
+	resp, err = client.Get(slow.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if _, err := ioutil.ReadAll(resp.Body); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
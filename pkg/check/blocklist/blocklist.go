@@ -0,0 +1,194 @@
+package blocklist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	DisconnectWait   time.Duration // time within which disconnected peer has to be seen disconnected, as it may be re-dialled
+	NodeGroup        string
+	PollInterval     time.Duration
+	ReconnectTimeout time.Duration
+	Seed             int64
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		DisconnectWait:   time.Second,
+		NodeGroup:        "bee",
+		PollInterval:     2 * time.Second,
+		ReconnectTimeout: 5 * time.Minute,
+		Seed:             random.Int64(),
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct{}
+
+// NewCheck returns new check
+func NewCheck() beekeeper.Action {
+	return &Check{}
+}
+
+// Run executes blocklist check
+//
+// Debug API of Bee v0.5.3 has no endpoint that blocks a peer, it only lists
+// blocked peers (GET /blocklist) and disconnects peers (DELETE
+// /peers/{address}). Peers are blocked by the node itself when they misbehave,
+// so the check verifies that disconnected peer is not blocked and reconnects.
+func (c *Check) Run(ctx context.Context, cluster *bee.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	rnd := random.PseudoGenerator(o.Seed)
+	fmt.Printf("Seed: %d\n", o.Seed)
+
+	ng, err := cluster.NodeGroup(o.NodeGroup)
+	if err != nil {
+		return err
+	}
+	clients, err := ng.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+	overlays, err := ng.Overlays(ctx)
+	if err != nil {
+		return err
+	}
+	names := make(map[string]string, len(overlays))
+	for name, a := range overlays {
+		names[a.String()] = name
+	}
+
+	sorted := ng.NodesSorted()
+	node := sorted[rnd.Intn(len(sorted))]
+	client := clients[node]
+
+	peers, err := client.Peers(ctx)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", node, err)
+	}
+	// only peers in the node group are checked, as both sides are verified
+	var candidates []string
+	for _, p := range peers {
+		if name, ok := names[p.String()]; ok {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf("node %s: no peers in node group %s", node, o.NodeGroup)
+	}
+	sort.Strings(candidates)
+	disconnected := candidates[rnd.Intn(len(candidates))]
+
+	// disconnected peer has to disappear from both sides and may be
+	// re-dialled afterwards
+	if err := client.DisconnectPeer(ctx, overlays[disconnected]); err != nil {
+		return fmt.Errorf("node %s: %w", node, err)
+	}
+	start := time.Now()
+	fmt.Printf("node %s: peer %s disconnected\n", node, disconnected)
+
+	if err := waitConnected(ctx, clients, overlays, node, disconnected, false, o.PollInterval, o.DisconnectWait); err != nil {
+		return err
+	}
+
+	for _, p := range [][2]string{{node, disconnected}, {disconnected, node}} {
+		list, err := clients[p[0]].Blocklist(ctx)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", p[0], err)
+		}
+		if contains(list, overlays[p[1]]) {
+			return fmt.Errorf("node %s: peer %s blocked after disconnect", p[0], p[1])
+		}
+	}
+
+	if err := waitConnected(ctx, clients, overlays, node, disconnected, true, o.PollInterval, o.ReconnectTimeout); err != nil {
+		return err
+	}
+	fmt.Printf("node %s: peer %s reconnected after %s\n", node, disconnected, time.Since(start).Round(time.Second))
+
+	fmt.Println("blocklist check completed successfully")
+	return
+}
+
+// waitConnected polls both nodes until they are connected or disconnected as
+// expected
+func waitConnected(ctx context.Context, clients map[string]*bee.Client, overlays bee.NodeGroupOverlays, a, b string, expected bool, interval, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		err := expectConnected(ctx, clients, overlays, a, b, expected)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w after %s", err, timeout)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// expectConnected returns error unless both nodes list each other in peers and
+// topology bins as expected
+func expectConnected(ctx context.Context, clients map[string]*bee.Client, overlays bee.NodeGroupOverlays, a, b string, expected bool) error {
+	for _, p := range [][2]string{{a, b}, {b, a}} {
+		node, peer := p[0], p[1]
+
+		peers, err := clients[node].Peers(ctx)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", node, err)
+		}
+		if contains(peers, overlays[peer]) != expected {
+			return fmt.Errorf("node %s: peer %s connected %t, expected %t", node, peer, !expected, expected)
+		}
+
+		t, err := clients[node].Topology(ctx)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", node, err)
+		}
+		inBins := false
+		for _, bin := range t.Bins {
+			if contains(bin.ConnectedPeers, overlays[peer]) {
+				inBins = true
+				break
+			}
+		}
+		if inBins != expected {
+			return fmt.Errorf("node %s: peer %s in connected topology bins %t, expected %t", node, peer, !expected, expected)
+		}
+	}
+
+	return nil
+}
+
+func contains(l []swarm.Address, a swarm.Address) bool {
+	for _, v := range l {
+		if v.Equal(a) {
+			return true
+		}
+	}
+	return false
+}
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethersphere/bee/pkg/swarm"
 	"github.com/ethersphere/beekeeper/pkg/bee"
 	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/swap"
 )
 
 // TODO: remove need for node group, use whole cluster instead
@@ -43,6 +46,15 @@ type CashoutAction struct {
 	uncashedAmount  *big.Int
 	transactionHash string
 	oldBalance      *big.Int
+	status          bee.CashoutStatusResponse // set when cashout is confirmed
+}
+
+// chequebook represents node's chequebook, which is both the recipient of
+// node's cashouts and the source of cheques node's peers cash out
+type chequebook struct {
+	node       string
+	address    string
+	oldBalance *big.Int // on-chain BZZ balance before cashouts
 }
 
 // Check executes settlements check
@@ -59,6 +71,29 @@ func (c *Check) Run(ctx context.Context, cluster *bee.Cluster, opts interface{})
 	sortedNodes := ng.NodesSorted()
 	var actions []CashoutAction
 
+	sc := cluster.SwapClient()
+	if _, ok := sc.(*swap.NotSet); ok {
+		fmt.Println("swap client not set, cashouts are not verified on chain")
+		sc = nil
+	}
+
+	// balances of all chequebooks are read before any cashout, as cashouts on
+	// one node take tokens from chequebooks of other nodes
+	chequebooks := make(map[string]chequebook)
+	if sc != nil {
+		for _, node := range sortedNodes {
+			client, err := ng.NodeClient(node)
+			if err != nil {
+				return err
+			}
+			cb, err := nodeChequebook(ctx, node, client, sc)
+			if err != nil {
+				return fmt.Errorf("node %s: %w", node, err)
+			}
+			chequebooks[strings.ToLower(cb.address)] = cb
+		}
+	}
+
 	for _, node := range sortedNodes {
 		client, err := ng.NodeClient(node)
 		if err != nil {
@@ -109,7 +144,7 @@ LOOP:
 	for i := 0; i < 10; i++ {
 		time.Sleep(5 * time.Second)
 
-		for _, action := range actions {
+		for i, action := range actions {
 			client, err := ng.NodeClient(action.node)
 			if err != nil {
 				return err
@@ -136,10 +171,125 @@ LOOP:
 			if action.oldBalance.Cmp(chequebookBalance.TotalBalance) == 0 {
 				return fmt.Errorf("chequebook balance not changed after cashout. was %d, now is %d", action.oldBalance, chequebookBalance.TotalBalance)
 			}
+
+			actions[i].status = cashoutStatus
+		}
+
+		if sc == nil {
+			return nil
 		}
 
-		return nil
+		return verifyOnChain(ctx, sc, actions, chequebooks)
 	}
 
 	return errors.New("not all cashouts confirmed")
 }
+
+// nodeChequebook returns node's chequebook with its current on-chain BZZ
+// balance, Bee cashes cheques out to its own chequebook
+func nodeChequebook(ctx context.Context, node string, client *bee.Client, sc swap.Client) (cb chequebook, err error) {
+	address, err := client.ChequebookAddress(ctx)
+	if err != nil {
+		return chequebook{}, err
+	}
+
+	balance, err := sc.BZZBalance(ctx, address)
+	if err != nil {
+		return chequebook{}, fmt.Errorf("bzz balance: %w", err)
+	}
+
+	return chequebook{node: node, address: address, oldBalance: balance}, nil
+}
+
+// verifyOnChain checks ChequeCashed events of cashout transactions against
+// cheques reported by nodes and verifies that BZZ balances of chequebooks
+// changed by payouts they received less payouts of cheques they issued, which
+// are cashed out by other nodes at the same time; cheques cashed out by nodes
+// outside of the node group during the check are not accounted for
+func verifyOnChain(ctx context.Context, sc swap.Client, actions []CashoutAction, chequebooks map[string]chequebook) (err error) {
+	changes := make(map[string]*big.Int)
+	change := func(address string, amount *big.Int, sign int) {
+		a := strings.ToLower(address)
+		if _, ok := changes[a]; !ok {
+			changes[a] = new(big.Int)
+		}
+		if sign < 0 {
+			changes[a].Sub(changes[a], amount)
+		} else {
+			changes[a].Add(changes[a], amount)
+		}
+	}
+
+	for _, action := range actions {
+		receipt, err := sc.TransactionReceipt(ctx, action.transactionHash)
+		if err != nil {
+			return fmt.Errorf("transaction %s receipt: %w", action.transactionHash, err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return fmt.Errorf("transaction %s failed", action.transactionHash)
+		}
+
+		events, err := swap.ChequeCashedEvents(receipt)
+		if err != nil {
+			return fmt.Errorf("transaction %s: %w", action.transactionHash, err)
+		}
+
+		cheque, result := action.status.Cheque, action.status.Result
+		if cheque == nil {
+			return fmt.Errorf("cashed cheque not reported on %s for peer %s", action.node, action.peer)
+		}
+
+		var event *swap.ChequeCashed
+		for i := range events {
+			if strings.EqualFold(events[i].Chequebook, cheque.Chequebook) {
+				event = &events[i]
+				break
+			}
+		}
+		if event == nil {
+			return fmt.Errorf("no ChequeCashed event for chequebook %s in transaction %s", cheque.Chequebook, action.transactionHash)
+		}
+
+		if !strings.EqualFold(event.Beneficiary, cheque.Beneficiary) {
+			return fmt.Errorf("cashout on %s from peer %s: event beneficiary %s, cheque beneficiary %s", action.node, action.peer, event.Beneficiary, cheque.Beneficiary)
+		}
+		if !strings.EqualFold(event.Recipient, result.Recipient) {
+			return fmt.Errorf("cashout on %s from peer %s: event recipient %s, reported recipient %s", action.node, action.peer, event.Recipient, result.Recipient)
+		}
+		if event.TotalPayout.Cmp(result.LastPayout) != 0 {
+			return fmt.Errorf("cashout on %s from peer %s: event payout %d, reported payout %d", action.node, action.peer, event.TotalPayout, result.LastPayout)
+		}
+		if event.CumulativePayout.Cmp(cheque.Payout) != 0 {
+			return fmt.Errorf("cashout on %s from peer %s: event cumulative payout %d, cheque payout %d", action.node, action.peer, event.CumulativePayout, cheque.Payout)
+		}
+
+		if _, ok := chequebooks[strings.ToLower(event.Recipient)]; !ok {
+			return fmt.Errorf("cashout on %s from peer %s: recipient %s is not a chequebook of the node group, its balance before cashout is not known", action.node, action.peer, event.Recipient)
+		}
+
+		// recipient receives total payout less the caller's payout, which is
+		// sent to the caller, and both are taken from the issuing chequebook
+		change(event.Recipient, new(big.Int).Sub(event.TotalPayout, event.CallerPayout), 1)
+		change(event.Caller, event.CallerPayout, 1)
+		change(event.Chequebook, event.TotalPayout, -1)
+
+		fmt.Printf("cashout on %s from peer %s verified on chain, payout %d\n", action.node, action.peer, event.TotalPayout)
+	}
+
+	for address, expected := range changes {
+		cb, ok := chequebooks[address]
+		if !ok {
+			continue
+		}
+		balance, err := sc.BZZBalance(ctx, cb.address)
+		if err != nil {
+			return fmt.Errorf("node %s: bzz balance: %w", cb.node, err)
+		}
+
+		if diff := new(big.Int).Sub(balance, cb.oldBalance); diff.Cmp(expected) != 0 {
+			return fmt.Errorf("node %s: BZZ balance of chequebook %s changed by %d, expected %d", cb.node, cb.address, diff, expected)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,284 @@
+package chequebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/beeclient/debugapi"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/random"
+	"github.com/ethersphere/beekeeper/pkg/swap"
+)
+
+// Options represents check options
+type Options struct {
+	MaxAmount   int64 // maximal amount of BZZ token base units deposited or withdrawn in one operation
+	NodeGroup   string
+	Operations  int // number of deposit and withdraw pairs
+	Seed        int64
+	WaitTimeout time.Duration // time to wait for operation's transaction to change balances
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		MaxAmount:   10000000000000, // 0.001 BZZ
+		NodeGroup:   "bee",
+		Operations:  3,
+		Seed:        0,
+		WaitTimeout: 2 * time.Minute,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct{}
+
+// NewCheck returns new check
+func NewCheck() beekeeper.Action {
+	return &Check{}
+}
+
+// balances represents node's chequebook balances and on-chain token balances
+type balances struct {
+	total      *big.Int
+	available  *big.Int
+	chequebook *big.Int // on-chain, nil if swap client is not set
+	wallet     *big.Int // on-chain, nil if swap client is not set
+}
+
+// Run executes chequebook check
+func (c *Check) Run(ctx context.Context, cluster *bee.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	rnd := random.PseudoGenerator(o.Seed)
+	fmt.Printf("Seed: %d\n", o.Seed)
+
+	ng, err := cluster.NodeGroup(o.NodeGroup)
+	if err != nil {
+		return err
+	}
+
+	sc := cluster.SwapClient()
+	if _, ok := sc.(*swap.NotSet); ok {
+		fmt.Println("swap client not set, on-chain balances are not verified")
+		sc = nil
+	}
+
+	for _, node := range ng.NodesSorted() {
+		client, err := ng.NodeClient(node)
+		if err != nil {
+			return err
+		}
+
+		n := &chequebookNode{name: node, client: client, swap: sc, waitTimeout: o.WaitTimeout}
+		if sc != nil {
+			a, err := client.Addresses(ctx)
+			if err != nil {
+				return fmt.Errorf("node %s: %w", node, err)
+			}
+			n.wallet = a.Ethereum
+
+			if n.chequebook, err = client.ChequebookAddress(ctx); err != nil {
+				return fmt.Errorf("node %s: %w", node, err)
+			}
+		}
+
+		for i := 0; i < o.Operations; i++ {
+			b, err := n.balances(ctx)
+			if err != nil {
+				return fmt.Errorf("node %s: %w", node, err)
+			}
+
+			amount := big.NewInt(rnd.Int63n(o.MaxAmount) + 1)
+			if b.wallet != nil && b.wallet.Cmp(amount) < 0 {
+				amount.Set(b.wallet)
+			}
+			if amount.Sign() > 0 {
+				if err := n.deposit(ctx, b, amount); err != nil {
+					return fmt.Errorf("node %s: %w", node, err)
+				}
+			}
+
+			if b, err = n.balances(ctx); err != nil {
+				return fmt.Errorf("node %s: %w", node, err)
+			}
+
+			amount = big.NewInt(rnd.Int63n(o.MaxAmount) + 1)
+			if b.available.Cmp(amount) < 0 {
+				amount.Set(b.available)
+			}
+			if amount.Sign() > 0 {
+				if err := n.withdraw(ctx, b, amount); err != nil {
+					return fmt.Errorf("node %s: %w", node, err)
+				}
+			}
+		}
+
+		if err := n.overdraw(ctx); err != nil {
+			return fmt.Errorf("node %s: %w", node, err)
+		}
+
+		fmt.Printf("node %s: chequebook operations verified\n", node)
+	}
+
+	return
+}
+
+// chequebookNode represents node whose chequebook is checked
+type chequebookNode struct {
+	name        string
+	client      *bee.Client
+	swap        swap.Client
+	wallet      string
+	chequebook  string
+	waitTimeout time.Duration
+}
+
+// balances returns node's current balances
+func (n *chequebookNode) balances(ctx context.Context) (b balances, err error) {
+	r, err := n.client.ChequebookBalance(ctx)
+	if err != nil {
+		return balances{}, err
+	}
+	b.total, b.available = r.TotalBalance, r.AvailableBalance
+
+	if n.swap == nil {
+		return
+	}
+
+	if b.chequebook, err = n.swap.BZZBalance(ctx, n.chequebook); err != nil {
+		return balances{}, fmt.Errorf("chequebook token balance: %w", err)
+	}
+	if b.wallet, err = n.swap.BZZBalance(ctx, n.wallet); err != nil {
+		return balances{}, fmt.Errorf("wallet token balance: %w", err)
+	}
+
+	return
+}
+
+// deposit deposits amount to the chequebook and verifies balances
+func (n *chequebookNode) deposit(ctx context.Context, before balances, amount *big.Int) (err error) {
+	tx, err := n.client.ChequebookDeposit(ctx, amount)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("node %s: deposited %s to the chequebook in transaction %s\n", n.name, amount, tx)
+
+	return n.verify(ctx, before, amount)
+}
+
+// withdraw withdraws amount from the chequebook and verifies balances
+func (n *chequebookNode) withdraw(ctx context.Context, before balances, amount *big.Int) (err error) {
+	tx, err := n.client.ChequebookWithdraw(ctx, amount)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("node %s: withdrew %s from the chequebook in transaction %s\n", n.name, amount, tx)
+
+	return n.verify(ctx, before, new(big.Int).Neg(amount))
+}
+
+// overdraw verifies that withdrawing more than available balance and
+// depositing more than wallet balance are rejected with 400 Bad Request, as
+// Bee v0.5.3 debug API does for insufficient funds, and don't change balances
+func (n *chequebookNode) overdraw(ctx context.Context) (err error) {
+	before, err := n.balances(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = n.client.ChequebookWithdraw(ctx, new(big.Int).Add(before.available, big.NewInt(1)))
+	if err == nil {
+		return errors.New("withdraw over available balance not rejected")
+	}
+	if !debugapi.IsHTTPStatusErrorCode(err, http.StatusBadRequest) {
+		return fmt.Errorf("withdraw over available balance: %w", err)
+	}
+	fmt.Printf("node %s: withdraw over available balance rejected\n", n.name)
+
+	if before.wallet != nil {
+		_, err = n.client.ChequebookDeposit(ctx, new(big.Int).Add(before.wallet, big.NewInt(1)))
+		if err == nil {
+			return errors.New("deposit over wallet balance not rejected")
+		}
+		if !debugapi.IsHTTPStatusErrorCode(err, http.StatusBadRequest) {
+			return fmt.Errorf("deposit over wallet balance: %w", err)
+		}
+		fmt.Printf("node %s: deposit over wallet balance rejected\n", n.name)
+	}
+
+	after, err := n.balances(ctx)
+	if err != nil {
+		return err
+	}
+	if !after.equal(before) {
+		return fmt.Errorf("balances changed after rejected operations: was %s, now is %s", before, after)
+	}
+
+	return
+}
+
+// verify waits until chequebook balances change by delta and checks that
+// on-chain token balances agree
+func (n *chequebookNode) verify(ctx context.Context, before balances, delta *big.Int) (err error) {
+	want := balances{
+		total:     new(big.Int).Add(before.total, delta),
+		available: new(big.Int).Add(before.available, delta),
+	}
+	if before.wallet != nil {
+		want.chequebook = new(big.Int).Add(before.chequebook, delta)
+		want.wallet = new(big.Int).Sub(before.wallet, delta)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.waitTimeout)
+	defer cancel()
+
+	var got balances
+	for {
+		if got, err = n.balances(ctx); err != nil {
+			return err
+		}
+		if got.equal(want) {
+			return nil
+		}
+
+		select {
+		case <-time.After(5 * time.Second):
+		case <-ctx.Done():
+			return fmt.Errorf("balances not changed by %s: want %s, got %s", delta, want, got)
+		}
+	}
+}
+
+// equal reports whether balances are equal
+func (b balances) equal(o balances) bool {
+	return equalInt(b.total, o.total) && equalInt(b.available, o.available) && equalInt(b.chequebook, o.chequebook) && equalInt(b.wallet, o.wallet)
+}
+
+// String returns balances' string representation
+func (b balances) String() string {
+	s := fmt.Sprintf("total %s, available %s", b.total, b.available)
+	if b.wallet != nil {
+		s += fmt.Sprintf(", on-chain chequebook %s, wallet %s", b.chequebook, b.wallet)
+	}
+	return s
+}
+
+// equalInt reports whether big integers are equal, nil is equal only to nil
+func equalInt(a, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Cmp(b) == 0
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/ethersphere/bee/pkg/file/pipeline/builder"
 	"github.com/ethersphere/bee/pkg/storage"
-	"github.com/ethersphere/bee/pkg/swarm"
 	"github.com/ethersphere/beekeeper/pkg/bee"
 	"github.com/ethersphere/beekeeper/pkg/beeclient/api"
 	"github.com/ethersphere/beekeeper/pkg/beekeeper"
@@ -61,7 +60,7 @@ func (c *Check) Run(ctx context.Context, cluster *bee.Cluster, opts interface{})
 		return fmt.Errorf("unable to create content: %w", err)
 	}
 
-	store := &putterMock{exists: make(map[string]swarm.Address)}
+	store := bee.NewChunkCollector()
 	pipe := builder.NewPipelineBuilder(ctx, store, storage.ModePutUpload, false)
 	addr, err := builder.FeedPipeline(ctx, pipe, bytes.NewBuffer(content), int64(len(content)))
 	if err != nil {
@@ -127,30 +126,3 @@ func (c *Check) Run(ctx context.Context, cluster *bee.Cluster, opts interface{})
 
 	return nil
 }
-
-type putterMock struct {
-	chunks []swarm.Chunk
-	exists map[string]swarm.Address
-}
-
-func (pm *putterMock) Put(_ context.Context, _ storage.ModePut, chs ...swarm.Chunk) ([]bool, error) {
-	exists := make([]bool, len(chs))
-	for i, ch := range chs {
-		key := ch.Address().ByteString()
-		if _, ok := pm.exists[key]; ok {
-			exists[i] = true
-			continue
-		}
-		pm.chunks = append(pm.chunks, ch)
-		pm.exists[key] = ch.Address()
-	}
-	return exists, nil
-}
-
-func (pm *putterMock) Addresses() []swarm.Address {
-	addresses := make([]swarm.Address, 0, len(pm.exists))
-	for _, val := range pm.exists {
-		addresses = append(addresses, val)
-	}
-	return addresses
-}
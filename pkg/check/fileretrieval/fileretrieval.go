@@ -1,7 +1,6 @@
 package fileretrieval
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -107,7 +106,7 @@ func defaultCheck(ctx context.Context, cluster *bee.Cluster, o Options) (err err
 	for i := 0; i < o.UploadNodeCount; i++ {
 		nodeName := sortedNodes[i]
 		for j := 0; j < o.FilesPerNode; j++ {
-			file := bee.NewRandomFile(rnds[i], fmt.Sprintf("%s-%d-%d", o.FileName, i, j), o.FileSize)
+			file := bee.NewSeededFile(rnds[i].Int63(), fmt.Sprintf("%s-%d-%d", o.FileName, i, j), o.FileSize)
 
 			depth := 2 + bee.EstimatePostageBatchDepth(file.Size())
 			batchID, err := clients[nodeName].CreatePostageBatch(ctx, o.PostageAmount, depth, o.GasPrice, o.PostageLabel, false)
@@ -135,8 +134,8 @@ func defaultCheck(ctx context.Context, cluster *bee.Cluster, o Options) (err err
 
 			client = clients[lastNodeName]
 
-			size, hash, err := client.DownloadFile(ctx, file.Address())
-			if err != nil {
+			err = client.VerifyFile(ctx, &file)
+			if err != nil && !errors.Is(err, bee.ErrFileMismatch) {
 				return fmt.Errorf("node %s: %w", lastNodeName, err)
 			}
 			d1 := time.Since(t1)
@@ -145,9 +144,9 @@ func defaultCheck(ctx context.Context, cluster *bee.Cluster, o Options) (err err
 			downloadTimeGauge.WithLabelValues(overlays[nodeName].String(), file.Address().String()).Set(d1.Seconds())
 			downloadTimeHistogram.Observe(d1.Seconds())
 
-			if !bytes.Equal(file.Hash(), hash) {
+			if err != nil {
 				notRetrievedCounter.WithLabelValues(overlays[nodeName].String()).Inc()
-				fmt.Printf("Node %s. File %d not retrieved successfully. Uploaded size: %d Downloaded size: %d Node: %s File: %s\n", nodeName, j, file.Size(), size, overlays[nodeName].String(), file.Address().String())
+				fmt.Printf("Node %s. File %d not retrieved successfully. Node: %s File: %s: %v\n", nodeName, j, overlays[nodeName].String(), file.Address().String(), err)
 				return errFileRetrieval
 			}
 
@@ -199,7 +198,7 @@ func fullCheck(ctx context.Context, cluster *bee.Cluster, o Options) (err error)
 	for i := 0; i < o.UploadNodeCount; i++ {
 		nodeName := sortedNodes[i]
 		for j := 0; j < o.FilesPerNode; j++ {
-			file := bee.NewRandomFile(rnds[i], fmt.Sprintf("%s-%d-%d", o.FileName, i, j), o.FileSize)
+			file := bee.NewSeededFile(rnds[i].Int63(), fmt.Sprintf("%s-%d-%d", o.FileName, i, j), o.FileSize)
 
 			depth := 2 + bee.EstimatePostageBatchDepth(file.Size())
 			batchID, err := clients[nodeName].CreatePostageBatch(ctx, o.PostageAmount, depth, o.GasPrice, o.PostageLabel, false)
@@ -226,8 +225,8 @@ func fullCheck(ctx context.Context, cluster *bee.Cluster, o Options) (err error)
 				}
 
 				t1 := time.Now()
-				size, hash, err := nc.DownloadFile(ctx, file.Address())
-				if err != nil {
+				err := nc.VerifyFile(ctx, &file)
+				if err != nil && !errors.Is(err, bee.ErrFileMismatch) {
 					return fmt.Errorf("node %s: %w", n, err)
 				}
 				d1 := time.Since(t1)
@@ -236,9 +235,9 @@ func fullCheck(ctx context.Context, cluster *bee.Cluster, o Options) (err error)
 				downloadTimeGauge.WithLabelValues(overlays[nodeName].String(), file.Address().String()).Set(d1.Seconds())
 				downloadTimeHistogram.Observe(d1.Seconds())
 
-				if !bytes.Equal(file.Hash(), hash) {
+				if err != nil {
 					notRetrievedCounter.WithLabelValues(overlays[nodeName].String()).Inc()
-					fmt.Printf("Node %s. File %d not retrieved successfully from node %s. Uploaded size: %d Downloaded size: %d Node: %s Download node: %s File: %s\n", nodeName, j, n, file.Size(), size, overlays[nodeName].String(), overlays[n].String(), file.Address().String())
+					fmt.Printf("Node %s. File %d not retrieved successfully from node %s. Node: %s Download node: %s File: %s: %v\n", nodeName, j, n, overlays[nodeName].String(), overlays[n].String(), file.Address().String(), err)
 					return errFileRetrieval
 				}
 
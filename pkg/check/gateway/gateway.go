@@ -0,0 +1,332 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/beeclient/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	DataSize      int64
+	GasPrice      string
+	PostageAmount int64
+	PostageDepth  uint64
+	PostageLabel  string
+	PostageWait   time.Duration
+	Seed          int64
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		DataSize:      1024,
+		GasPrice:      "",
+		PostageAmount: 1000,
+		PostageDepth:  16,
+		PostageLabel:  "test-label",
+		PostageWait:   5 * time.Second,
+		Seed:          0,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct{}
+
+// NewCheck returns new check
+func NewCheck() beekeeper.Action {
+	return &Check{}
+}
+
+// probe represents request to the endpoint, forbidden requests have to be
+// rejected by the gateway mode with 403 Forbidden, others have to get the same
+// response as from the node that doesn't run in gateway mode
+type probe struct {
+	name      string
+	forbidden bool
+	call      func(ctx context.Context, c *bee.Client, p probeData) error
+}
+
+// probeData represents data probes use
+type probeData struct {
+	address   swarm.Address
+	batchID   string
+	data      []byte
+	overlay   swarm.Address
+	pssPubKey string
+}
+
+// probes follow the api router of Bee v0.5.3 (pkg/api/router.go), where
+// gatewayModeForbidEndpointHandler guards /pss, /tags, /pin/chunks,
+// /pin/bytes, /pin/files and /pin/bzz endpoints and
+// gatewayModeForbidHeadersHandler rejects requests with Swarm-Pin or
+// Swarm-Encrypt header; stewardship is not probed as v0.5.3 has no
+// /stewardship endpoint
+var probes = []probe{
+	{
+		name: "download bytes",
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			_, _, err := c.DownloadBytes(ctx, p.address)
+			return err
+		},
+	},
+	{
+		name: "stamped upload",
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			_, err := c.UploadBytes(ctx, p.data, api.UploadOptions{BatchID: p.batchID})
+			return err
+		},
+	},
+	{
+		name: "unstamped upload",
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			_, err := c.UploadBytes(ctx, p.data, api.UploadOptions{})
+			return err
+		},
+	},
+	{
+		name: "soc upload",
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			// the chunk is invalid, both nodes have to reject it the same way
+			owner, id, sig := strings.Repeat("00", 20), strings.Repeat("00", 32), strings.Repeat("00", 65)
+			_, err := c.UploadSOC(ctx, owner, id, sig, p.data, p.batchID)
+			return err
+		},
+	},
+	{
+		name:      "pinned upload",
+		forbidden: true,
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			_, err := c.UploadBytes(ctx, p.data, api.UploadOptions{BatchID: p.batchID, Pin: true})
+			return err
+		},
+	},
+	{
+		name:      "encrypted upload",
+		forbidden: true,
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			_, err := c.UploadBytes(ctx, p.data, api.UploadOptions{BatchID: p.batchID, Encrypt: true})
+			return err
+		},
+	},
+	{
+		name:      "pin chunk",
+		forbidden: true,
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			return c.PinChunk(ctx, p.address)
+		},
+	},
+	{
+		name:      "unpin chunk",
+		forbidden: true,
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			return c.UnpinChunk(ctx, p.address)
+		},
+	},
+	{
+		name:      "get pinned chunk",
+		forbidden: true,
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			_, err := c.PinCounter(ctx, p.address)
+			return err
+		},
+	},
+	{
+		name:      "list pinned chunks",
+		forbidden: true,
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			_, err := c.PinnedChunks(ctx)
+			return err
+		},
+	},
+	{
+		name:      "pin bytes",
+		forbidden: true,
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			return c.PinBytes(ctx, p.address)
+		},
+	},
+	{
+		name:      "pin file",
+		forbidden: true,
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			// the request is rejected before the file is looked up
+			return c.PinFile(ctx, p.address)
+		},
+	},
+	{
+		name:      "pin collection",
+		forbidden: true,
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			// the request is rejected before the collection is looked up
+			return c.PinCollection(ctx, p.address)
+		},
+	},
+	{
+		name:      "create tag",
+		forbidden: true,
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			_, err := c.CreateTag(ctx)
+			return err
+		},
+	},
+	{
+		name:      "get tag",
+		forbidden: true,
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			// the request is rejected before the tag is looked up
+			_, err := c.GetTag(ctx, 1)
+			return err
+		},
+	},
+	{
+		name:      "pss send",
+		forbidden: true,
+		call: func(ctx context.Context, c *bee.Client, p probeData) error {
+			return c.SendPSSMessage(ctx, p.overlay, p.pssPubKey, "gateway", 1, p.data, p.batchID)
+		},
+	},
+}
+
+// responseCode returns HTTP status code of the failed request or zero if the
+// request succeeded
+func responseCode(err error) (int, error) {
+	if err == nil {
+		return 0, nil
+	}
+	var e *api.HTTPStatusError
+	if errors.As(err, &e) {
+		return e.Code, nil
+	}
+	return 0, err
+}
+
+// formatCode returns description of the response code
+func formatCode(code int) string {
+	if code == 0 {
+		return "success"
+	}
+	return fmt.Sprintf("%d %s", code, http.StatusText(code))
+}
+
+// Run executes gateway mode check
+func (c *Check) Run(ctx context.Context, cluster *bee.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	rnd := random.PseudoGenerator(o.Seed)
+	fmt.Printf("Seed: %d\n", o.Seed)
+
+	var gateways []*bee.Node
+	var reference *bee.Node
+	for _, g := range cluster.NodeGroupsSorted() {
+		ng, err := cluster.NodeGroup(g)
+		if err != nil {
+			return err
+		}
+
+		for _, node := range ng.NodesSorted() {
+			n, err := ng.Node(node)
+			if err != nil {
+				return err
+			}
+			if n.Config() == nil {
+				continue
+			}
+			if n.Config().GatewayMode {
+				gateways = append(gateways, n)
+			} else if reference == nil {
+				reference = n
+			}
+		}
+	}
+
+	if len(gateways) == 0 {
+		return errors.New("no nodes running in gateway mode")
+	}
+	if reference == nil {
+		return errors.New("no nodes running without gateway mode to compare responses with")
+	}
+
+	data := make([]byte, o.DataSize)
+	if _, err := rnd.Read(data); err != nil {
+		return err
+	}
+	ref, err := newProbeData(ctx, reference.Client(), reference.Name(), data, o)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("node %s: responses of gateway nodes are compared with this node\n", reference.Name())
+
+	for _, n := range gateways {
+		p, err := newProbeData(ctx, n.Client(), n.Name(), data, o)
+		if err != nil {
+			return err
+		}
+
+		for _, pr := range probes {
+			code, err := responseCode(pr.call(ctx, n.Client(), p))
+			if err != nil {
+				return fmt.Errorf("node %s: %s in gateway mode: %w", n.Name(), pr.name, err)
+			}
+			if pr.forbidden {
+				if code != http.StatusForbidden {
+					return fmt.Errorf("node %s: %s in gateway mode: expected %s, got %s", n.Name(), pr.name, formatCode(http.StatusForbidden), formatCode(code))
+				}
+				continue
+			}
+
+			refCode, err := responseCode(pr.call(ctx, reference.Client(), ref))
+			if err != nil {
+				return fmt.Errorf("node %s: %s: %w", reference.Name(), pr.name, err)
+			}
+			if code != refCode {
+				return fmt.Errorf("node %s: %s in gateway mode: expected %s as from node %s, got %s", n.Name(), pr.name, formatCode(refCode), reference.Name(), formatCode(code))
+			}
+		}
+		fmt.Printf("node %s: gateway mode restricts sensitive operations\n", n.Name())
+	}
+
+	fmt.Printf("gateway mode check of %d nodes completed successfully\n", len(gateways))
+	return
+}
+
+// newProbeData buys postage batch and uploads data probes download
+func newProbeData(ctx context.Context, client *bee.Client, node string, data []byte, o Options) (p probeData, err error) {
+	batchID, err := client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return probeData{}, fmt.Errorf("node %s: batch id %w", node, err)
+	}
+	fmt.Printf("node %s: batch id %s\n", node, batchID)
+	time.Sleep(o.PostageWait)
+
+	addresses, err := client.Addresses(ctx)
+	if err != nil {
+		return probeData{}, fmt.Errorf("node %s: %w", node, err)
+	}
+
+	p = probeData{
+		batchID:   batchID,
+		data:      data,
+		overlay:   addresses.Overlay,
+		pssPubKey: addresses.PSSPublicKey,
+	}
+	if p.address, err = client.UploadBytes(ctx, data, api.UploadOptions{BatchID: batchID}); err != nil {
+		return probeData{}, fmt.Errorf("node %s: %w", node, err)
+	}
+	return p, nil
+}
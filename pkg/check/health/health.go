@@ -0,0 +1,275 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+)
+
+// statusOK is status reported by healthy node
+const statusOK = "ok"
+
+// Options represents check options
+type Options struct {
+	// AllowMixedVersions allows nodes to report different versions
+	AllowMixedVersions bool
+	// CheckImageTag requires version of the node to match tag of its image,
+	// images tagged with anything but a version, e.g. latest, are not matched
+	CheckImageTag bool
+	// MaxVersion is the highest allowed version, it is not checked if empty
+	MaxVersion string
+	// MinVersion is the lowest allowed version, it is not checked if empty
+	MinVersion string
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		AllowMixedVersions: false,
+		CheckImageTag:      true,
+		MaxVersion:         "",
+		MinVersion:         "",
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct{}
+
+// NewCheck returns new check
+func NewCheck() beekeeper.Action {
+	return &Check{}
+}
+
+// nodeHealth represents health of the node and the image it is configured with
+type nodeHealth struct {
+	group string
+	name  string
+	image string
+	bee.Health
+	problems []string
+}
+
+// Run runs the check
+func (c *Check) Run(ctx context.Context, cluster *bee.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	var minVersion, maxVersion version
+	if len(o.MinVersion) > 0 {
+		if minVersion, err = parseVersion(o.MinVersion); err != nil {
+			return fmt.Errorf("min version: %w", err)
+		}
+	}
+	if len(o.MaxVersion) > 0 {
+		if maxVersion, err = parseVersion(o.MaxVersion); err != nil {
+			return fmt.Errorf("max version: %w", err)
+		}
+	}
+
+	var nodes []*nodeHealth
+	for _, g := range cluster.NodeGroupsSorted() {
+		ng, err := cluster.NodeGroup(g)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range ng.NodesSorted() {
+			n := &nodeHealth{group: g, name: name}
+			nodes = append(nodes, n)
+
+			if n.image, err = ng.NodeImage(name); err != nil {
+				return err
+			}
+			client, err := ng.NodeClient(name)
+			if err != nil {
+				return err
+			}
+			if n.Health, err = client.Health(ctx); err != nil {
+				n.problems = append(n.problems, err.Error())
+				continue
+			}
+
+			if n.Status != statusOK {
+				n.problems = append(n.problems, fmt.Sprintf("status %q", n.Status))
+			}
+
+			v, err := parseVersion(n.Version)
+			if err != nil {
+				n.problems = append(n.problems, err.Error())
+				continue
+			}
+			if len(o.MinVersion) > 0 && v.compare(minVersion) < 0 {
+				n.problems = append(n.problems, fmt.Sprintf("version lower than %s", o.MinVersion))
+			}
+			if len(o.MaxVersion) > 0 && v.compare(maxVersion) > 0 {
+				n.problems = append(n.problems, fmt.Sprintf("version higher than %s", o.MaxVersion))
+			}
+			if o.CheckImageTag {
+				if tag, ok := versionTag(n.image); ok && !matchesTag(n.Version, tag) {
+					n.problems = append(n.problems, fmt.Sprintf("version doesn't match image tag %s", tag))
+				}
+			}
+		}
+	}
+
+	// nodes running other than the most common versions are left behind by
+	// partial rollouts, API versions are compared too as they may change with
+	// the same node version; Bee v0.5.3 /health reports only status and
+	// version, so API versions are empty for its nodes
+	versions := make(map[string]int)
+	for _, n := range nodes {
+		if v := n.versions(); len(v) > 0 {
+			versions[v]++
+		}
+	}
+	if len(versions) > 1 {
+		common := mostCommon(versions)
+		fmt.Printf("mixed versions: %s\n", formatCounts(versions))
+		if !o.AllowMixedVersions {
+			for _, n := range nodes {
+				if v := n.versions(); len(v) > 0 && v != common {
+					n.problems = append(n.problems, fmt.Sprintf("versions differ from %s run by %d nodes", common, versions[common]))
+				}
+			}
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE GROUP\tNODE\tSTATUS\tVERSION\tAPI\tDEBUG API\tIMAGE\tPROBLEMS")
+	unhealthy := 0
+	for _, n := range nodes {
+		if len(n.problems) > 0 {
+			unhealthy++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", n.group, n.name, n.Status, n.Version, n.APIVersion, n.DebugAPIVersion, n.image, strings.Join(n.problems, "; "))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if unhealthy > 0 {
+		return fmt.Errorf("%d of %d nodes failed health check", unhealthy, len(nodes))
+	}
+	fmt.Printf("all %d nodes are healthy\n", len(nodes))
+
+	return nil
+}
+
+// versions returns node's version with API and debug API versions, it is
+// empty if the node didn't report them
+func (n *nodeHealth) versions() string {
+	if len(n.Version) == 0 && len(n.APIVersion) == 0 && len(n.DebugAPIVersion) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s (API %s, debug API %s)", n.Version, n.APIVersion, n.DebugAPIVersion)
+}
+
+// version represents major, minor and patch numbers of the version
+type version [3]int
+
+// parseVersion parses version like v1.2.3-rc1-abcdef, pre-release and build
+// suffixes are ignored
+func parseVersion(s string) (v version, err error) {
+	core := strings.TrimPrefix(s, "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) > len(v) {
+		return version{}, fmt.Errorf("invalid version %q", s)
+	}
+	for i, p := range parts {
+		if v[i], err = strconv.Atoi(p); err != nil {
+			return version{}, fmt.Errorf("invalid version %q", s)
+		}
+	}
+
+	return v, nil
+}
+
+// compare returns -1, 0 or 1 if the version is lower, equal or higher than w
+func (v version) compare(w version) int {
+	for i := range v {
+		switch {
+		case v[i] < w[i]:
+			return -1
+		case v[i] > w[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionTag returns tag of the image if it is a version
+func versionTag(image string) (tag string, ok bool) {
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return "", false
+	}
+	tag = image[i+1:]
+	if _, err := parseVersion(tag); err != nil {
+		return "", false
+	}
+
+	return tag, true
+}
+
+// matchesTag returns true if version is the tag, optionally followed by commit
+// hash, e.g. 1.2.0-abcdef12 matches tag 1.2.0
+func matchesTag(version, tag string) bool {
+	tag = strings.TrimPrefix(tag, "v")
+	version = strings.TrimPrefix(version, "v")
+	return version == tag || strings.HasPrefix(version, tag+"-")
+}
+
+// mostCommon returns the most common versions, ties are broken by the highest
+// node version
+func mostCommon(versions map[string]int) (common string) {
+	for v, count := range versions {
+		if count > versions[common] || (count == versions[common] && newer(v, common)) {
+			common = v
+		}
+	}
+	return
+}
+
+// newer returns true if node version of versions a is higher than of b,
+// versions that can't be parsed are the lowest and equal node versions are
+// compared as strings, so the order doesn't depend on the map iteration
+func newer(a, b string) bool {
+	va, errA := parseVersion(strings.SplitN(a, " ", 2)[0])
+	vb, errB := parseVersion(strings.SplitN(b, " ", 2)[0])
+	switch {
+	case errA == nil && errB != nil:
+		return true
+	case errA != nil && errB == nil:
+		return false
+	case errA == nil && errB == nil:
+		if c := va.compare(vb); c != 0 {
+			return c > 0
+		}
+	}
+	return a > b
+}
+
+// formatCounts formats node counts by version
+func formatCounts(versions map[string]int) string {
+	l := make([]string, 0, len(versions))
+	for v, count := range versions {
+		l = append(l, fmt.Sprintf("%s (%d nodes)", v, count))
+	}
+	sort.Strings(l)
+	return strings.Join(l, ", ")
+}
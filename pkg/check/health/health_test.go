@@ -0,0 +1,146 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/bee"
+)
+
+func TestParseVersion(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		want    version
+		wantErr bool
+	}{
+		{version: "1.2.3", want: version{1, 2, 3}},
+		{version: "v1.2.3", want: version{1, 2, 3}},
+		{version: "0.5.3-acbd0e2", want: version{0, 5, 3}},
+		{version: "1.2.0-rc1-abcdef12", want: version{1, 2, 0}},
+		{version: "1.2.3+build", want: version{1, 2, 3}},
+		{version: "1.2", want: version{1, 2, 0}},
+		{version: "1", want: version{1, 0, 0}},
+		{version: "", wantErr: true},
+		{version: "latest", wantErr: true},
+		{version: "1.2.3.4", wantErr: true},
+		{version: "1..3", wantErr: true},
+		{version: "1.x.3", wantErr: true},
+	} {
+		t.Run(tc.version, func(t *testing.T) {
+			got, err := parseVersion(tc.version)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	for _, tc := range []struct {
+		a, b version
+		want int
+	}{
+		{a: version{1, 2, 3}, b: version{1, 2, 3}, want: 0},
+		{a: version{1, 2, 3}, b: version{1, 2, 4}, want: -1},
+		{a: version{1, 3, 0}, b: version{1, 2, 9}, want: 1},
+		{a: version{0, 9, 9}, b: version{1, 0, 0}, want: -1},
+		{a: version{2, 0, 0}, b: version{1, 9, 9}, want: 1},
+	} {
+		if got := tc.a.compare(tc.b); got != tc.want {
+			t.Fatalf("%v compared to %v: got %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestVersionTag(t *testing.T) {
+	for _, tc := range []struct {
+		image  string
+		tag    string
+		wantOK bool
+	}{
+		{image: "ethersphere/bee:0.5.3", tag: "0.5.3", wantOK: true},
+		{image: "ethersphere/bee:v1.2.0", tag: "v1.2.0", wantOK: true},
+		{image: "registry:5000/ethersphere/bee:1.2.0", tag: "1.2.0", wantOK: true},
+		{image: "ethersphere/bee:latest"},
+		{image: "ethersphere/bee"},
+		{image: "registry:5000/ethersphere/bee"},
+	} {
+		t.Run(tc.image, func(t *testing.T) {
+			tag, ok := versionTag(tc.image)
+			if ok != tc.wantOK || tag != tc.tag {
+				t.Fatalf("got tag %q and %t, want %q and %t", tag, ok, tc.tag, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestMatchesTag(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		tag     string
+		want    bool
+	}{
+		{version: "0.5.3", tag: "0.5.3", want: true},
+		{version: "0.5.3-acbd0e2", tag: "0.5.3", want: true},
+		{version: "v0.5.3", tag: "0.5.3", want: true},
+		{version: "0.5.3", tag: "v0.5.3", want: true},
+		{version: "1.2.0-rc1-abcdef12", tag: "1.2.0-rc1", want: true},
+		{version: "1.2.0-rc1-abcdef12", tag: "1.2.0", want: true},
+		{version: "0.5.30", tag: "0.5.3", want: false},
+		{version: "0.5.4", tag: "0.5.3", want: false},
+		{version: "0.5", tag: "0.5.3", want: false},
+	} {
+		t.Run(tc.version+" "+tc.tag, func(t *testing.T) {
+			if got := matchesTag(tc.version, tc.tag); got != tc.want {
+				t.Fatalf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMostCommon(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		versions map[string]int
+		want     string
+	}{
+		{name: "empty", versions: map[string]int{}, want: ""},
+		{name: "single", versions: map[string]int{"1.0.0": 3}, want: "1.0.0"},
+		{name: "most nodes", versions: map[string]int{"1.0.0": 1, "1.1.0": 4, "1.2.0": 2}, want: "1.1.0"},
+		{name: "tie", versions: map[string]int{"1.0.0": 2, "1.1.0": 2, "0.9.0": 1}, want: "1.1.0"},
+		{name: "tie by semver", versions: map[string]int{"0.9.0": 2, "0.10.0": 2}, want: "0.10.0"},
+		{name: "tie with api versions", versions: map[string]int{"0.9.0 (API , debug API )": 1, "0.10.0 (API , debug API )": 1}, want: "0.10.0 (API , debug API )"},
+		{name: "tie with unparsable", versions: map[string]int{"latest": 1, "0.1.0": 1}, want: "0.1.0"},
+		{name: "tie same version", versions: map[string]int{"1.0.0 (API 1.0.0, debug API 1.0.0)": 1, "1.0.0 (API 1.1.0, debug API 1.0.0)": 1}, want: "1.0.0 (API 1.1.0, debug API 1.0.0)"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// map iteration order is random, so the result must not depend on it
+			for i := 0; i < 20; i++ {
+				if got := mostCommon(tc.versions); got != tc.want {
+					t.Fatalf("got %q, want %q", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNodeVersions(t *testing.T) {
+	a := nodeHealth{Health: bee.Health{Version: "1.0.0", APIVersion: "1.0.0", DebugAPIVersion: "1.0.0"}}
+	b := nodeHealth{Health: bee.Health{Version: "1.0.0", APIVersion: "1.0.0", DebugAPIVersion: "1.1.0"}}
+	c := nodeHealth{Health: bee.Health{Version: "1.0.0", APIVersion: "1.1.0", DebugAPIVersion: "1.0.0"}}
+
+	if a.versions() == b.versions() || a.versions() == c.versions() || b.versions() == c.versions() {
+		t.Fatalf("got the same versions for different API versions: %q, %q and %q", a.versions(), b.versions(), c.versions())
+	}
+	if got := (&nodeHealth{}).versions(); got != "" {
+		t.Fatalf("got versions %q of node that didn't report them", got)
+	}
+}
@@ -57,37 +57,25 @@ func (c *Check) Run(ctx context.Context, cluster *bee.Cluster, opts interface{})
 			return fmt.Errorf("get nodes clients: %w", err)
 		}
 
+		// failed requests are retried by the client's transport
 		for n := range nodeStream(ctx, nodesClients) { // TODO: confirm use case for nodeStream(ctx, ng.NodesClientsAll(ctx))
-			for t := 0; t < 5; t++ {
-				time.Sleep(2 * time.Duration(t) * time.Second)
-
-				if n.Error != nil {
-					if t == 4 {
-						return fmt.Errorf("node %s: %w", n.Name, n.Error)
-					}
-					fmt.Printf("node %s: %v\n", n.Name, n.Error)
-					continue
-				}
-				fmt.Printf("Node %s: %s Peer: %s RTT: %s\n", n.Name, n.Address, n.PeerAddress, n.RTT)
+			if n.Error != nil {
+				return fmt.Errorf("node %s: %w", n.Name, n.Error)
+			}
+			fmt.Printf("Node %s: %s Peer: %s RTT: %s\n", n.Name, n.Address, n.PeerAddress, n.RTT)
 
-				rtt, err := time.ParseDuration(n.RTT)
-				if err != nil {
-					if t == 4 {
-						return fmt.Errorf("node %s: %w", n.Name, err)
-					}
-					fmt.Printf("node %s: %v\n", n.Name, err)
-					continue
-				}
+			rtt, err := time.ParseDuration(n.RTT)
+			if err != nil {
+				return fmt.Errorf("node %s: %w", n.Name, err)
+			}
 
-				rttGauge.WithLabelValues(n.Address.String(), n.PeerAddress.String()).Set(rtt.Seconds())
-				rttHistogram.Observe(rtt.Seconds())
+			rttGauge.WithLabelValues(n.Address.String(), n.PeerAddress.String()).Set(rtt.Seconds())
+			rttHistogram.Observe(rtt.Seconds())
 
-				if o.MetricsPusher != nil {
-					if err := o.MetricsPusher.Push(); err != nil {
-						fmt.Printf("node %s: %v\n", n.Name, err)
-					}
+			if o.MetricsPusher != nil {
+				if err := o.MetricsPusher.Push(); err != nil {
+					fmt.Printf("node %s: %v\n", n.Name, err)
 				}
-				break
 			}
 		}
 	}
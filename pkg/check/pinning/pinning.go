@@ -0,0 +1,423 @@
+package pinning
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/beeclient/api"
+	"github.com/ethersphere/beekeeper/pkg/beeclient/debugapi"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+// Options represents check options
+type Options struct {
+	ChunkCount      int // number of single chunk uploads, half of them is pinned
+	CollectionFiles int // number of files in the pinned collection
+	FileSize        int64
+	GasPrice        string
+	NodeGroup       string
+	PinNodeCount    int // number of nodes that pin content
+	PostageAmount   int64
+	PostageDepth    uint64
+	PostageLabel    string
+	PostageWait     time.Duration
+	PressureSize    int64 // size of unpinned data uploaded to the pinning node to make it evict chunks
+	RequireEviction bool  // fail if no unpinned chunk was evicted under cache pressure
+	Restart         bool  // restart pinning nodes and verify that pins persist
+	Seed            int64
+}
+
+// NewDefaultOptions returns new default options
+func NewDefaultOptions() Options {
+	return Options{
+		ChunkCount:      10,
+		CollectionFiles: 3,
+		FileSize:        1 * 1024 * 1024, // 1mb
+		GasPrice:        "",
+		NodeGroup:       "bee",
+		PinNodeCount:    1,
+		PostageAmount:   1000,
+		PostageDepth:    20,
+		PostageLabel:    "test-label",
+		PostageWait:     5 * time.Second,
+		PressureSize:    20 * 1024 * 1024, // 20mb
+		RequireEviction: false,
+		Restart:         true,
+		Seed:            0,
+	}
+}
+
+// compile check whether Check implements interface
+var _ beekeeper.Action = (*Check)(nil)
+
+// Check instance
+type Check struct{}
+
+// NewCheck returns new check
+func NewCheck() beekeeper.Action {
+	return &Check{}
+}
+
+var errPinning = errors.New("pinning")
+
+// Run executes pinning check
+//
+// Bee v0.5.3 pins content chunk by chunk and keeps pin counter of every chunk,
+// pins are listed as pinned chunks (GET /pin/chunks) and root hashes are
+// verified by pin counters of their root chunks (GET /pin/chunks/{address}).
+func (c *Check) Run(ctx context.Context, cluster *bee.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	rnd := random.PseudoGenerator(o.Seed)
+	fmt.Printf("Seed: %d\n", o.Seed)
+
+	ng, err := cluster.NodeGroup(o.NodeGroup)
+	if err != nil {
+		return err
+	}
+
+	sorted := ng.NodesSorted()
+	if len(sorted) < 2 || o.PinNodeCount >= len(sorted) {
+		return fmt.Errorf("node group %s has %d nodes, pinning on %d nodes needs more", o.NodeGroup, len(sorted), o.PinNodeCount)
+	}
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, i := range rnd.Perm(len(sorted))[:o.PinNodeCount] {
+		name := sorted[i]
+		if err := checkNode(ctx, ng, name, clients, rnd, o); err != nil {
+			return fmt.Errorf("node %s: %w", name, err)
+		}
+	}
+
+	fmt.Println("pinning check completed successfully")
+	return
+}
+
+// checkNode pins content on the node and verifies that the node keeps it
+func checkNode(ctx context.Context, ng *bee.NodeGroup, name string, clients map[string]*bee.Client, rnd *rand.Rand, o Options) (err error) {
+	client := clients[name]
+
+	batchID, err := client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return fmt.Errorf("batch id %w", err)
+	}
+	fmt.Printf("node %s: batch id %s\n", name, batchID)
+	time.Sleep(o.PostageWait)
+
+	c, err := upload(ctx, client, rnd, batchID, o)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("node %s: pinned file %s, collection %s and %d chunks\n", name, c.file.Address(), c.collection.Address(), len(c.pinnedChunks()))
+
+	if err := c.verifyPins(ctx, client, true); err != nil {
+		return err
+	}
+	fmt.Printf("node %s: pins listed\n", name)
+
+	for n, nc := range clients {
+		if n == name {
+			continue
+		}
+		for _, a := range c.pinnedAddresses() {
+			if err := nc.RemoveChunk(ctx, a); err != nil && !debugapi.IsHTTPStatusErrorCode(err, http.StatusNotFound) {
+				return fmt.Errorf("remove chunk %s from node %s: %w", a, n, err)
+			}
+		}
+	}
+	if err := c.verifyServed(ctx, client); err != nil {
+		return fmt.Errorf("after pinned chunks were removed from other nodes: %w", err)
+	}
+	fmt.Printf("node %s: serves pinned content removed from other nodes\n", name)
+
+	if o.Restart {
+		if err := ng.StopNode(ctx, name); err != nil {
+			return fmt.Errorf("stop: %w", err)
+		}
+		if err := ng.StartNode(ctx, name); err != nil {
+			return fmt.Errorf("start: %w", err)
+		}
+		if err := c.verifyPins(ctx, client, true); err != nil {
+			return fmt.Errorf("after restart: %w", err)
+		}
+		if err := c.verifyServed(ctx, client); err != nil {
+			return fmt.Errorf("after restart: %w", err)
+		}
+		fmt.Printf("node %s: pins persisted restart\n", name)
+	}
+
+	if err := client.UnpinFile(ctx, c.file.Address()); err != nil {
+		return fmt.Errorf("unpin file %s: %w", c.file.Address(), err)
+	}
+	if err := client.UnpinCollection(ctx, c.collection.Address()); err != nil {
+		return fmt.Errorf("unpin collection %s: %w", c.collection.Address(), err)
+	}
+	if err := c.verifyPins(ctx, client, false); err != nil {
+		return fmt.Errorf("after unpin: %w", err)
+	}
+	fmt.Printf("node %s: file and collection unpinned\n", name)
+
+	pressure := bee.NewSeededFile(rnd.Int63(), "pressure", o.PressureSize)
+	if err := client.UploadFile(ctx, &pressure, api.UploadOptions{BatchID: batchID}); err != nil {
+		return fmt.Errorf("cache pressure: %w", err)
+	}
+	fmt.Printf("node %s: uploaded %d bytes of unpinned data\n", name, o.PressureSize)
+
+	pinned := c.pinnedChunks()
+	_, count, err := client.HasChunks(ctx, pinned)
+	if err != nil {
+		return err
+	}
+	if count != len(pinned) {
+		fmt.Printf("node %s: %d of %d pinned chunks evicted under cache pressure\n", name, len(pinned)-count, len(pinned))
+		return errPinning
+	}
+	unpinned := append(c.unpinnedChunks(), c.file.Address(), c.collection.Address())
+	_, count, err = client.HasChunks(ctx, unpinned)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("node %s: pinned chunks kept, %d of %d unpinned chunks evicted under cache pressure\n", name, len(unpinned)-count, len(unpinned))
+	if o.RequireEviction && count == len(unpinned) {
+		return errors.New("no unpinned chunk evicted under cache pressure, pressure size has to be larger")
+	}
+
+	for _, a := range pinned {
+		if err := client.UnpinChunk(ctx, a); err != nil {
+			return fmt.Errorf("unpin chunk %s: %w", a, err)
+		}
+	}
+	pins, err := client.PinnedChunks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, a := range c.allRoots() {
+		if containsAddress(pins, a) {
+			return fmt.Errorf("%s still listed after unpin", a)
+		}
+	}
+	fmt.Printf("node %s: all pins removed\n", name)
+
+	return
+}
+
+// content represents content uploaded to the pinning node
+type content struct {
+	file       bee.File
+	collection bee.File
+	files      []bee.File // files in the collection
+	chunks     []chunk
+}
+
+// chunk represents data uploaded as a single chunk
+type chunk struct {
+	address swarm.Address
+	data    []byte
+	pinned  bool
+}
+
+// upload uploads pinned file, collection pinned after the upload and single
+// chunks, half of them pinned
+func upload(ctx context.Context, client *bee.Client, rnd *rand.Rand, batchID string, o Options) (c content, err error) {
+	c.file = bee.NewSeededFile(rnd.Int63(), "pinned-file", o.FileSize)
+	if err := client.UploadFile(ctx, &c.file, api.UploadOptions{BatchID: batchID, Pin: true}); err != nil {
+		return content{}, err
+	}
+
+	if c.files, err = generateFiles(rnd, o.CollectionFiles); err != nil {
+		return content{}, err
+	}
+	tarReader, err := tarFiles(c.files)
+	if err != nil {
+		return content{}, err
+	}
+	c.collection = bee.NewBufferFile("", tarReader)
+	if err := client.UploadCollection(ctx, &c.collection, api.UploadOptions{BatchID: batchID}); err != nil {
+		return content{}, err
+	}
+	if err := client.PinCollection(ctx, c.collection.Address()); err != nil {
+		return content{}, fmt.Errorf("pin collection %s: %w", c.collection.Address(), err)
+	}
+
+	for i := 0; i < o.ChunkCount; i++ {
+		ch := chunk{data: make([]byte, swarm.ChunkSize), pinned: i%2 == 0}
+		if _, err := rnd.Read(ch.data); err != nil {
+			return content{}, err
+		}
+		if ch.address, err = client.UploadBytes(ctx, ch.data, api.UploadOptions{BatchID: batchID, Pin: ch.pinned}); err != nil {
+			return content{}, err
+		}
+		c.chunks = append(c.chunks, ch)
+	}
+
+	return
+}
+
+// verifyPins verifies that pinned chunks are pinned and root chunks of the
+// file and the collection are pinned or not
+func (c *content) verifyPins(ctx context.Context, client *bee.Client, rootsPinned bool) (err error) {
+	pins, err := client.PinnedChunks(ctx)
+	if err != nil {
+		return err
+	}
+
+	want := map[string]bool{
+		c.file.Address().String():       rootsPinned,
+		c.collection.Address().String(): rootsPinned,
+	}
+	for _, ch := range c.chunks {
+		want[ch.address.String()] = ch.pinned
+	}
+
+	for _, a := range c.allRoots() {
+		pinned := want[a.String()]
+		if containsAddress(pins, a) != pinned {
+			return fmt.Errorf("%s pinned %t, listed %t", a, pinned, !pinned)
+		}
+
+		counter, err := client.PinCounter(ctx, a)
+		if err != nil {
+			return err
+		}
+		if pinned != (counter > 0) {
+			return fmt.Errorf("%s pinned %t, pin counter %d", a, pinned, counter)
+		}
+	}
+
+	return
+}
+
+// verifyServed verifies that the node serves pinned content
+func (c *content) verifyServed(ctx context.Context, client *bee.Client) (err error) {
+	for _, ch := range c.chunks {
+		if !ch.pinned {
+			continue
+		}
+		if err := client.VerifyBytes(ctx, ch.address, bytes.NewReader(ch.data)); err != nil {
+			if errors.Is(err, bee.ErrFileMismatch) {
+				return fmt.Errorf("chunk %s: %w", ch.address, errPinning)
+			}
+			return err
+		}
+	}
+
+	if err := client.VerifyFile(ctx, &c.file); err != nil {
+		return err
+	}
+
+	for _, f := range c.files {
+		_, hash, err := client.DownloadManifestFile(ctx, c.collection.Address(), f.Name())
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(hash, f.Hash()) {
+			return fmt.Errorf("collection %s file %s: %w", c.collection.Address(), f.Name(), errPinning)
+		}
+	}
+
+	return
+}
+
+// allRoots returns addresses of all uploaded roots
+func (c *content) allRoots() (addrs []swarm.Address) {
+	addrs = append(addrs, c.file.Address(), c.collection.Address())
+	for _, ch := range c.chunks {
+		addrs = append(addrs, ch.address)
+	}
+	return
+}
+
+// pinnedChunks returns addresses of pinned single chunks
+func (c *content) pinnedChunks() (addrs []swarm.Address) {
+	for _, ch := range c.chunks {
+		if ch.pinned {
+			addrs = append(addrs, ch.address)
+		}
+	}
+	return
+}
+
+// pinnedAddresses returns addresses of pinned chunks whose presence can be
+// checked, only root chunks of the file and the collection are known
+func (c *content) pinnedAddresses() []swarm.Address {
+	return append(c.pinnedChunks(), c.file.Address(), c.collection.Address())
+}
+
+// unpinnedChunks returns addresses of single chunks that were never pinned
+func (c *content) unpinnedChunks() (addrs []swarm.Address) {
+	for _, ch := range c.chunks {
+		if !ch.pinned {
+			addrs = append(addrs, ch.address)
+		}
+	}
+	return
+}
+
+// generateFiles returns files with random names and content for the collection
+func generateFiles(r *rand.Rand, count int) ([]bee.File, error) {
+	files := make([]bee.File, count)
+	for i := range files {
+		b := make([]byte, 8)
+		if _, err := r.Read(b); err != nil {
+			return nil, err
+		}
+
+		files[i] = bee.NewRandomFile(r, hex.EncodeToString(b), int64(r.Intn(10*1024)+1))
+		if err := files[i].CalculateHash(); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// tarFiles returns tar archive of the files
+func tarFiles(files []bee.File) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	for _, file := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: file.Name(), Mode: 0600, Size: file.Size()}); err != nil {
+			return nil, err
+		}
+		data := bytes.NewBuffer(nil)
+		if _, err := data.ReadFrom(file.DataReader()); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(data.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+func containsAddress(addrs []swarm.Address, a swarm.Address) bool {
+	for _, v := range addrs {
+		if v.Equal(a) {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package smoke
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"time"
@@ -109,15 +110,13 @@ func (c *Check) Run(ctx context.Context, cluster *bee.Cluster, opts interface{})
 			return err
 		}
 
-		dd, err := dClient.DownloadBytes(ctx, addr)
-		if err != nil {
+		if err := dClient.VerifyBytes(ctx, addr, bytes.NewReader(data)); err != nil {
+			if errors.Is(err, bee.ErrFileMismatch) {
+				return fmt.Errorf("download data mismatch: %w", err)
+			}
 			return fmt.Errorf("download from node %s: %w", nodeName, err)
 		}
 
-		if !bytes.Equal(data, dd) {
-			return fmt.Errorf("download data mismatch")
-		}
-
 		fmt.Printf("Downloaded successfully from node: %s\n", downloadNode)
 	}
 	fmt.Println("smoke test completed successfully")
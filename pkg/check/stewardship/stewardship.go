@@ -0,0 +1,252 @@
+package stewardship
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethersphere/bee/pkg/file/pipeline/builder"
+	"github.com/ethersphere/bee/pkg/storage"
+	"github.com/ethersphere/bee/pkg/swarm"
+	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/beeclient/api"
+	"github.com/ethersphere/beekeeper/pkg/beekeeper"
+	"github.com/ethersphere/beekeeper/pkg/random"
+)
+
+var _ beekeeper.Action = (*Check)(nil)
+
+// errStewardshipUnavailable is returned if the node doesn't route the
+// stewardship API
+var errStewardshipUnavailable = errors.New("stewardship API not available, it isn't routed by Bee v0.5.3")
+
+// Check instance.
+type Check struct{}
+
+// NewCheck returns a new check instance.
+func NewCheck() beekeeper.Action { return &Check{} }
+
+// Options groups a set of options that can be set for this check.
+type Options struct {
+	ContentSize     int64
+	GasPrice        string
+	PollInterval    time.Duration
+	PostageAmount   int64
+	PostageDepth    uint64
+	PostageLabel    string
+	PostageWait     time.Duration
+	RecoveryTimeout time.Duration
+	RemoveTimeout   time.Duration
+	Seed            int64
+	SyncWait        time.Duration
+}
+
+// NewDefaultOptions returns new default options.
+func NewDefaultOptions() Options {
+	return Options{
+		ContentSize:     1024 << 6,
+		GasPrice:        "",
+		PollInterval:    2 * time.Second,
+		PostageAmount:   1000,
+		PostageDepth:    16,
+		PostageLabel:    "test-label",
+		PostageWait:     5 * time.Second,
+		RecoveryTimeout: 5 * time.Minute,
+		RemoveTimeout:   2 * time.Minute,
+		Seed:            0,
+		SyncWait:        5 * time.Second,
+	}
+}
+
+// Run executes stewardship check
+//
+// The check needs the stewardship API (GET and PUT /stewardship/{address}),
+// which isn't routed by Bee v0.5.3 Beekeeper is pinned to, so nodes running
+// v0.5.3 fail the check with errStewardshipUnavailable.
+func (c *Check) Run(ctx context.Context, cluster *bee.Cluster, opts interface{}) (err error) {
+	o, ok := opts.(Options)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	rnd := random.PseudoGenerator(o.Seed)
+	fmt.Printf("seed: %d\n", o.Seed)
+
+	names := cluster.NodeNames()
+	if len(names) < 2 {
+		return errors.New("stewardship check requires at least two nodes")
+	}
+	pinner := names[rnd.Intn(len(names))]
+
+	content := make([]byte, o.ContentSize)
+	if _, err := rnd.Read(content); err != nil {
+		return fmt.Errorf("unable to create content: %w", err)
+	}
+
+	store := bee.NewChunkCollector()
+	pipe := builder.NewPipelineBuilder(ctx, store, storage.ModePutUpload, false)
+	root, err := builder.FeedPipeline(ctx, pipe, bytes.NewBuffer(content), int64(len(content)))
+	if err != nil {
+		return fmt.Errorf("unable to feed pipeline: %w", err)
+	}
+	addresses := store.Addresses()
+	if len(addresses) == 0 {
+		return errors.New("empty list of addresses")
+	}
+
+	clients, err := cluster.NodesClients(ctx)
+	if err != nil {
+		return err
+	}
+	client := clients[pinner]
+
+	overlays, err := cluster.FlattenOverlays(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("node %s: %s pins content of %d chunks\n", pinner, overlays[pinner], len(addresses))
+
+	batchID, err := client.GetOrCreateBatch(ctx, o.PostageAmount, o.PostageDepth, o.GasPrice, o.PostageLabel)
+	if err != nil {
+		return fmt.Errorf("node %s: unable to create batch id: %w", pinner, err)
+	}
+	fmt.Printf("node %s: batch id %s\n", pinner, batchID)
+	time.Sleep(o.PostageWait)
+
+	ref, err := client.UploadBytes(ctx, content, api.UploadOptions{BatchID: batchID, Pin: true})
+	if err != nil {
+		return fmt.Errorf("node %s: unable to upload content: %w", pinner, err)
+	}
+	if !ref.Equal(root) {
+		return fmt.Errorf("node %s: uploaded content reference %s, expected %s", pinner, ref, root)
+	}
+	fmt.Printf("node %s: content uploaded and pinned: %s\n", pinner, ref)
+
+	time.Sleep(o.SyncWait)
+
+	if err := waitRetrievable(ctx, client, ref, true, o.PollInterval, o.RecoveryTimeout); err != nil {
+		return fmt.Errorf("node %s: uploaded content: %w", pinner, err)
+	}
+	fmt.Printf("node %s: uploaded content is retrievable\n", pinner)
+
+	// chunks are removed again until content is not retrievable, as they may
+	// be synced back from the pinning node meanwhile
+	removeCtx, cancel := context.WithTimeout(ctx, o.RemoveTimeout)
+	defer cancel()
+	for round := 1; ; round++ {
+		for _, name := range names {
+			if name == pinner {
+				continue
+			}
+			for _, a := range addresses {
+				if err := clients[name].RemoveChunk(removeCtx, a); err != nil {
+					return fmt.Errorf("node %s: unable to remove chunk %s: %w", name, a, err)
+				}
+			}
+		}
+		fmt.Printf("round %d: %d chunks removed from %d nodes\n", round, len(addresses), len(names)-1)
+
+		retrievable, err := client.IsRetrievable(removeCtx, ref)
+		if err != nil {
+			return fmt.Errorf("node %s: unable to check if content is retrievable: %w", pinner, err)
+		}
+		if !retrievable {
+			break
+		}
+
+		select {
+		case <-removeCtx.Done():
+			return fmt.Errorf("node %s: content is still retrievable after removing its chunks: %w", pinner, removeCtx.Err())
+		case <-time.After(o.PollInterval):
+		}
+	}
+	fmt.Printf("node %s: content is not retrievable\n", pinner)
+
+	// time to recovery includes the reupload, as the content is not
+	// retrievable until its chunks are pushed back
+	start := time.Now()
+	if err := client.Reupload(ctx, ref); err != nil {
+		return fmt.Errorf("node %s: unable to reupload content: %w", pinner, err)
+	}
+	fmt.Printf("node %s: content reuploaded in %s\n", pinner, time.Since(start))
+
+	if err := waitRetrievable(ctx, client, ref, true, o.PollInterval, o.RecoveryTimeout); err != nil {
+		return fmt.Errorf("node %s: reuploaded content: %w", pinner, err)
+	}
+	fmt.Printf("node %s: content is retrievable again, time to recovery %s\n", pinner, time.Since(start))
+
+	// every chunk has to be stored by the node closest to it, chunks closest
+	// to the pinning node are stored by it already
+	for _, a := range addresses {
+		closest, err := closestNode(a, overlays)
+		if err != nil {
+			return err
+		}
+		if closest == pinner {
+			continue
+		}
+		has, err := clients[closest].HasChunk(ctx, a)
+		if err != nil {
+			return fmt.Errorf("node %s: unable to check chunk %s: %w", closest, a, err)
+		}
+		if !has {
+			return fmt.Errorf("node %s: reuploaded chunk %s not found on the closest node", closest, a)
+		}
+	}
+	fmt.Printf("all %d reuploaded chunks are found on their closest nodes\n", len(addresses))
+
+	return nil
+}
+
+// waitRetrievable polls the node until retrievability of the content is as
+// expected
+func waitRetrievable(ctx context.Context, client *bee.Client, ref swarm.Address, expected bool, interval, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		retrievable, err := client.IsRetrievable(ctx, ref)
+		if api.IsHTTPStatusErrorCode(err, http.StatusNotFound) {
+			// content is stored on the node, not found response comes from
+			// the router
+			return fmt.Errorf("%w: %v", errStewardshipUnavailable, err)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to check if content is retrievable: %w", err)
+		}
+		if retrievable == expected {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("retrievable is not %t after %s: %w", expected, timeout, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// closestNode returns name of the node whose overlay is closest to the address
+func closestNode(a swarm.Address, overlays map[string]swarm.Address) (closest string, err error) {
+	for name, overlay := range overlays {
+		if closest == "" {
+			closest = name
+			continue
+		}
+		dcmp, err := swarm.DistanceCmp(a.Bytes(), overlay.Bytes(), overlays[closest].Bytes())
+		if err != nil {
+			return "", fmt.Errorf("find closest node: %w", err)
+		}
+		if dcmp == 1 {
+			closest = name
+		}
+	}
+	if closest == "" {
+		return "", errors.New("closest node not found")
+	}
+
+	return closest, nil
+}
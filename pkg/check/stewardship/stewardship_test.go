@@ -0,0 +1,86 @@
+package stewardship
+
+import (
+	"testing"
+
+	"github.com/ethersphere/bee/pkg/swarm"
+)
+
+func TestClosestNode(t *testing.T) {
+	overlays := map[string]swarm.Address{
+		"bee-0": swarm.MustParseHexAddress("0000000000000000000000000000000000000000000000000000000000000000"),
+		"bee-1": swarm.MustParseHexAddress("8000000000000000000000000000000000000000000000000000000000000000"),
+		"bee-2": swarm.MustParseHexAddress("c000000000000000000000000000000000000000000000000000000000000000"),
+		"bee-3": swarm.MustParseHexAddress("c100000000000000000000000000000000000000000000000000000000000000"),
+	}
+
+	for _, tc := range []struct {
+		name     string
+		address  string
+		overlays map[string]swarm.Address
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "no nodes",
+			address:  "0000000000000000000000000000000000000000000000000000000000000000",
+			overlays: map[string]swarm.Address{},
+			wantErr:  true,
+		},
+		{
+			name:     "single node",
+			address:  "ff00000000000000000000000000000000000000000000000000000000000000",
+			overlays: map[string]swarm.Address{"bee-0": overlays["bee-0"]},
+			want:     "bee-0",
+		},
+		{
+			name:     "equal to overlay",
+			address:  "8000000000000000000000000000000000000000000000000000000000000000",
+			overlays: overlays,
+			want:     "bee-1",
+		},
+		{
+			name:     "first bit",
+			address:  "1000000000000000000000000000000000000000000000000000000000000000",
+			overlays: overlays,
+			want:     "bee-0",
+		},
+		{
+			name:     "longest common prefix",
+			address:  "a000000000000000000000000000000000000000000000000000000000000000",
+			overlays: overlays,
+			want:     "bee-1",
+		},
+		{
+			name:     "distance in later bytes",
+			address:  "c1000000000000000000000000000000000000000000000000000000000000ff",
+			overlays: overlays,
+			want:     "bee-3",
+		},
+		{
+			name:     "xor distance",
+			address:  "c080000000000000000000000000000000000000000000000000000000000000",
+			overlays: overlays,
+			want:     "bee-2",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// map iteration order is random, so the result must not depend on it
+			for i := 0; i < 20; i++ {
+				got, err := closestNode(swarm.MustParseHexAddress(tc.address), tc.overlays)
+				if tc.wantErr {
+					if err == nil {
+						t.Fatalf("expected error, got %s", got)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != tc.want {
+					t.Fatalf("got %s, want %s", got, tc.want)
+				}
+			}
+		})
+	}
+}
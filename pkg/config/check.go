@@ -7,16 +7,21 @@ import (
 
 	"github.com/ethersphere/beekeeper/pkg/beekeeper"
 	"github.com/ethersphere/beekeeper/pkg/check/balances"
+	"github.com/ethersphere/beekeeper/pkg/check/blocklist"
 	"github.com/ethersphere/beekeeper/pkg/check/cashout"
+	"github.com/ethersphere/beekeeper/pkg/check/chequebook"
 	"github.com/ethersphere/beekeeper/pkg/check/chunkrepair"
 	"github.com/ethersphere/beekeeper/pkg/check/contentavailability"
 	"github.com/ethersphere/beekeeper/pkg/check/fileretrieval"
 	"github.com/ethersphere/beekeeper/pkg/check/fullconnectivity"
+	"github.com/ethersphere/beekeeper/pkg/check/gateway"
 	"github.com/ethersphere/beekeeper/pkg/check/gc"
+	"github.com/ethersphere/beekeeper/pkg/check/health"
 	"github.com/ethersphere/beekeeper/pkg/check/kademlia"
 	"github.com/ethersphere/beekeeper/pkg/check/manifest"
 	"github.com/ethersphere/beekeeper/pkg/check/peercount"
 	"github.com/ethersphere/beekeeper/pkg/check/pingpong"
+	"github.com/ethersphere/beekeeper/pkg/check/pinning"
 	"github.com/ethersphere/beekeeper/pkg/check/pss"
 	"github.com/ethersphere/beekeeper/pkg/check/pullsync"
 	"github.com/ethersphere/beekeeper/pkg/check/pushsync"
@@ -24,6 +29,7 @@ import (
 	"github.com/ethersphere/beekeeper/pkg/check/settlements"
 	"github.com/ethersphere/beekeeper/pkg/check/smoke"
 	"github.com/ethersphere/beekeeper/pkg/check/soc"
+	"github.com/ethersphere/beekeeper/pkg/check/stewardship"
 	"github.com/ethersphere/beekeeper/pkg/random"
 	"github.com/prometheus/client_golang/prometheus/push"
 	"gopkg.in/yaml.v3"
@@ -78,6 +84,28 @@ var Checks = map[string]CheckType{
 			return opts, nil
 		},
 	},
+	"blocklist": {
+		NewAction: blocklist.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(struct {
+				DisconnectWait   *time.Duration `yaml:"disconnect-wait"`
+				NodeGroup        *string        `yaml:"node-group"`
+				PollInterval     *time.Duration `yaml:"poll-interval"`
+				ReconnectTimeout *time.Duration `yaml:"reconnect-timeout"`
+				Seed             *int64         `yaml:"seed"`
+			})
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := blocklist.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
 	"cashout": {
 		NewAction: cashout.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
@@ -96,6 +124,28 @@ var Checks = map[string]CheckType{
 			return opts, nil
 		},
 	},
+	"chequebook": {
+		NewAction: chequebook.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(struct {
+				MaxAmount   *int64         `yaml:"max-amount"`
+				NodeGroup   *string        `yaml:"node-group"`
+				Operations  *int           `yaml:"operations"`
+				Seed        *int64         `yaml:"seed"`
+				WaitTimeout *time.Duration `yaml:"wait-timeout"`
+			})
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := chequebook.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
 	"chunk-repair": {
 		NewAction: chunkrepair.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
@@ -169,6 +219,30 @@ var Checks = map[string]CheckType{
 			return opts, nil
 		},
 	},
+	"gateway": {
+		NewAction: gateway.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(struct {
+				DataSize      *int64         `yaml:"data-size"`
+				GasPrice      *string        `yaml:"gas-price"`
+				PostageAmount *int64         `yaml:"postage-amount"`
+				PostageDepth  *uint64        `yaml:"postage-depth"`
+				PostageLabel  *string        `yaml:"postage-label"`
+				PostageWait   *time.Duration `yaml:"postage-wait"`
+				Seed          *int64         `yaml:"seed"`
+			})
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := gateway.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
 	"gc": {
 		NewAction: gc.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
@@ -191,6 +265,27 @@ var Checks = map[string]CheckType{
 			return opts, nil
 		},
 	},
+	"health": {
+		NewAction: health.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(struct {
+				AllowMixedVersions *bool   `yaml:"allow-mixed-versions"`
+				CheckImageTag      *bool   `yaml:"check-image-tag"`
+				MaxVersion         *string `yaml:"max-version"`
+				MinVersion         *string `yaml:"min-version"`
+			})
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := health.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
 	"kademlia": {
 		NewAction: kademlia.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
@@ -258,6 +353,37 @@ var Checks = map[string]CheckType{
 			return opts, nil
 		},
 	},
+	"pinning": {
+		NewAction: pinning.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(struct {
+				ChunkCount      *int           `yaml:"chunk-count"`
+				CollectionFiles *int           `yaml:"collection-files"`
+				FileSize        *int64         `yaml:"file-size"`
+				GasPrice        *string        `yaml:"gas-price"`
+				NodeGroup       *string        `yaml:"node-group"`
+				PinNodeCount    *int           `yaml:"pin-node-count"`
+				PostageAmount   *int64         `yaml:"postage-amount"`
+				PostageDepth    *uint64        `yaml:"postage-depth"`
+				PostageLabel    *string        `yaml:"postage-label"`
+				PostageWait     *time.Duration `yaml:"postage-wait"`
+				PressureSize    *int64         `yaml:"pressure-size"`
+				RequireEviction *bool          `yaml:"require-eviction"`
+				Restart         *bool          `yaml:"restart"`
+				Seed            *int64         `yaml:"seed"`
+			})
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := pinning.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
 	"pss": {
 		NewAction: pss.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
@@ -442,6 +568,34 @@ var Checks = map[string]CheckType{
 			return opts, nil
 		},
 	},
+	"stewardship": {
+		NewAction: stewardship.NewCheck,
+		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
+			checkOpts := new(struct {
+				ContentSize     *int64         `yaml:"content-size"`
+				GasPrice        *string        `yaml:"gas-price"`
+				PollInterval    *time.Duration `yaml:"poll-interval"`
+				PostageAmount   *int64         `yaml:"postage-amount"`
+				PostageDepth    *uint64        `yaml:"postage-depth"`
+				PostageLabel    *string        `yaml:"postage-label"`
+				PostageWait     *time.Duration `yaml:"postage-wait"`
+				RecoveryTimeout *time.Duration `yaml:"recovery-timeout"`
+				RemoveTimeout   *time.Duration `yaml:"remove-timeout"`
+				Seed            *int64         `yaml:"seed"`
+				SyncWait        *time.Duration `yaml:"sync-wait"`
+			})
+			if err := check.Options.Decode(checkOpts); err != nil {
+				return nil, fmt.Errorf("decoding check %s options: %w", check.Type, err)
+			}
+			opts := stewardship.NewDefaultOptions()
+
+			if err := applyCheckConfig(checkGlobalConfig, checkOpts, &opts); err != nil {
+				return nil, fmt.Errorf("applying options: %w", err)
+			}
+
+			return opts, nil
+		},
+	},
 	"content-availability": {
 		NewAction: contentavailability.NewCheck,
 		NewOptions: func(checkGlobalConfig CheckGlobalConfig, check Check) (interface{}, error) {
@@ -2,6 +2,7 @@ package config
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/ethersphere/beekeeper/pkg/bee"
 )
@@ -17,11 +18,16 @@ type Cluster struct {
 	APIDomain           *string                      `yaml:"api-domain"`
 	APIInsecureTLS      *bool                        `yaml:"api-insecure-tls"`
 	APIScheme           *string                      `yaml:"api-scheme"`
+	APIRetries          *int                         `yaml:"api-retries"`
+	APITimeout          *time.Duration               `yaml:"api-timeout"`
+	APIMaxConnsPerHost  *int                         `yaml:"api-max-conns-per-host"`
 	DebugAPIDomain      *string                      `yaml:"debug-api-domain"`
 	DebugAPIInsecureTLS *bool                        `yaml:"debug-api-insecure-tls"`
 	DebugAPIScheme      *string                      `yaml:"debug-api-scheme"`
 	Funding             *Funding                     `yaml:"funding"`
+	KeysSeed            *string                      `yaml:"keys-seed"`
 	NodeGroups          *map[string]ClusterNodeGroup `yaml:"node-groups"`
+	TLSCABundle         *string                      `yaml:"tls-ca-bundle"`
 }
 
 // ClusterNodeGroup represents node group in the cluster
@@ -31,6 +37,8 @@ type ClusterNodeGroup struct {
 	Config    string        `yaml:"config"`
 	Count     int           `yaml:"count"`
 	Nodes     []ClusterNode `yaml:"nodes"`
+	// funding targets of the node group's nodes, cluster's funding is used if not set
+	Funding *Funding `yaml:"funding"`
 }
 
 // ClusterNode represents node in the cluster
@@ -40,6 +48,9 @@ type ClusterNode struct {
 	Clef      Clef   `yaml:"clef"`
 	LibP2PKey string `yaml:"libp2p-key"`
 	SwarmKey  string `yaml:"swarm-key"`
+	// overrides of node group's config and bee-config, they inherit from node group's profiles unless _inherit is set
+	Config    *NodeGroup `yaml:"config"`
+	BeeConfig *BeeConfig `yaml:"bee-config"`
 }
 
 type Clef struct {
@@ -85,6 +96,34 @@ func (c *Cluster) GetNamespace() string {
 	return *c.Namespace
 }
 
+// GetKeysSeed returns seed nodes' keys are derived from
+func (c *Cluster) GetKeysSeed() string {
+	if c.KeysSeed == nil {
+		return ""
+	}
+	return *c.KeysSeed
+}
+
+// GetTLSCABundle returns path to the PEM file with CA certificates used to verify API and debug API
+func (c *Cluster) GetTLSCABundle() string {
+	if c.TLSCABundle == nil {
+		return ""
+	}
+	return *c.TLSCABundle
+}
+
+// GetFunding returns funding targets of the node group, cluster's funding
+// is used if the node group doesn't set its own
+func (c *Cluster) GetFunding(nodeGroup string) (f bee.FundingOptions) {
+	if ng, ok := c.GetNodeGroups()[nodeGroup]; ok && ng.Funding != nil {
+		return ng.Funding.Export()
+	}
+	if c.Funding == nil {
+		return
+	}
+	return c.Funding.Export()
+}
+
 // GetNodeGroups returns cluster node groups
 func (c *Cluster) GetNodeGroups() map[string]ClusterNodeGroup {
 	if c.NodeGroups == nil {
@@ -35,14 +35,8 @@ func (c *Config) merge() (err error) {
 			if !ok {
 				return fmt.Errorf("bee profile %s doesn't exist", v.ParentName)
 			}
-			p := reflect.ValueOf(&parent).Elem()
-			m := reflect.ValueOf(&v).Elem()
-			for i := 0; i < m.NumField(); i++ {
-				if m.Field(i).IsNil() && !p.Field(i).IsNil() {
-					m.Field(i).Set(p.Field(i))
-				}
-			}
-			mergedBC[name] = m.Interface().(BeeConfig)
+			inherit(&v, &parent)
+			mergedBC[name] = v
 		}
 	}
 	c.BeeConfigs = mergedBC
@@ -57,14 +51,8 @@ func (c *Config) merge() (err error) {
 			if !ok {
 				return fmt.Errorf("node group profile %s doesn't exist", v.ParentName)
 			}
-			p := reflect.ValueOf(&parent).Elem()
-			m := reflect.ValueOf(&v).Elem()
-			for i := 0; i < m.NumField(); i++ {
-				if m.Field(i).IsNil() && !p.Field(i).IsNil() {
-					m.Field(i).Set(p.Field(i))
-				}
-			}
-			mergedNG[name] = m.Interface().(NodeGroup)
+			inherit(&v, &parent)
+			mergedNG[name] = v
 		}
 	}
 	c.NodeGroups = mergedNG
@@ -79,21 +67,57 @@ func (c *Config) merge() (err error) {
 			if !ok {
 				return fmt.Errorf("bee profile %s doesn't exist", v.ParentName)
 			}
-			p := reflect.ValueOf(&parent).Elem()
-			m := reflect.ValueOf(&v).Elem()
-			for i := 0; i < m.NumField(); i++ {
-				if m.Field(i).IsNil() && !p.Field(i).IsNil() {
-					m.Field(i).Set(p.Field(i))
+			inherit(&v, &parent)
+			mergedC[name] = v
+		}
+	}
+	c.Clusters = mergedC
+
+	// merge nodes' overrides with node group's profiles
+	for cName, cluster := range c.Clusters {
+		for ngName, ng := range cluster.GetNodeGroups() {
+			for i, n := range ng.Nodes {
+				if n.Config != nil {
+					parentName := ng.Config
+					if n.Config.Inherit != nil && len(n.Config.ParentName) > 0 {
+						parentName = n.Config.ParentName
+					}
+					parent, ok := c.NodeGroups[parentName]
+					if !ok {
+						return fmt.Errorf("cluster %s node group %s node %d: node group profile %s doesn't exist", cName, ngName, i, parentName)
+					}
+					inherit(n.Config, &parent)
+				}
+				if n.BeeConfig != nil {
+					parentName := ng.BeeConfig
+					if n.BeeConfig.Inherit != nil && len(n.BeeConfig.ParentName) > 0 {
+						parentName = n.BeeConfig.ParentName
+					}
+					parent, ok := c.BeeConfigs[parentName]
+					if !ok {
+						return fmt.Errorf("cluster %s node group %s node %d: bee profile %s doesn't exist", cName, ngName, i, parentName)
+					}
+					inherit(n.BeeConfig, &parent)
 				}
 			}
-			mergedC[name] = m.Interface().(Cluster)
 		}
 	}
-	c.Clusters = mergedC
 
 	return
 }
 
+// inherit sets fields of v that are not set to values of the same fields of
+// the parent, v and parent must be pointers to the same struct type
+func inherit(v, parent interface{}) {
+	p := reflect.ValueOf(parent).Elem()
+	m := reflect.ValueOf(v).Elem()
+	for i := 0; i < m.NumField(); i++ {
+		if m.Field(i).IsNil() && !p.Field(i).IsNil() {
+			m.Field(i).Set(p.Field(i))
+		}
+	}
+}
+
 // ReadDir reads given directory for YAML files and unmarshals them into Config
 func ReadDir(configDir string) (*Config, error) {
 	// read all files from the directory
@@ -172,5 +196,24 @@ func ReadDir(configDir string) (*Config, error) {
 		return nil, fmt.Errorf("merging config: %w", err)
 	}
 
+	// validate merged BeeConfigs
+	for name, v := range c.BeeConfigs {
+		if err := v.validate(); err != nil {
+			return nil, fmt.Errorf("bee profile %s: %w", name, err)
+		}
+	}
+	for name, cluster := range c.Clusters {
+		for ng, v := range cluster.GetNodeGroups() {
+			for i, n := range v.Nodes {
+				if n.BeeConfig == nil {
+					continue
+				}
+				if err := n.BeeConfig.validate(); err != nil {
+					return nil, fmt.Errorf("cluster %s node group %s node %d bee config: %w", name, ng, i, err)
+				}
+			}
+		}
+	}
+
 	return &c, nil
 }
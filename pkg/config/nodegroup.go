@@ -4,6 +4,7 @@ import (
 	"reflect"
 
 	"github.com/ethersphere/beekeeper/pkg/bee"
+	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
 )
 
 // NodeGroup represents node group configuration
@@ -11,28 +12,36 @@ type NodeGroup struct {
 	// parent to inherit settings from
 	*Inherit `yaml:",inline"`
 	// node group configuration
-	Annotations               *map[string]string `yaml:"annotations"`
-	ClefImage                 *string            `yaml:"clef-image"`
-	ClefImagePullPolicy       *string            `yaml:"clef-image-pull-policy"`
-	Image                     *string            `yaml:"image"`
-	ImagePullPolicy           *string            `yaml:"image-pull-policy"`
-	ImagePullSecrets          *[]string          `yaml:"image-pull-secrets"`
-	IngressAnnotations        *map[string]string `yaml:"ingress-annotations"`
-	IngressClass              *string            `yaml:"ingress-class"`
-	IngressDebugAnnotations   *map[string]string `yaml:"ingress-debug-annotations"`
-	IngressDebugClass         *string            `yaml:"ingress-debug-class"`
-	Labels                    *map[string]string `yaml:"labels"`
-	NodeSelector              *map[string]string `yaml:"node-selector"`
-	PersistenceEnabled        *bool              `yaml:"persistence-enabled"`
-	PersistenceStorageClass   *string            `yaml:"persistence-storage-class"`
-	PersistenceStorageRequest *string            `yaml:"persistence-storage-request"`
-	PodManagementPolicy       *string            `yaml:"pod-management-policy"`
-	ResourcesLimitCPU         *string            `yaml:"resources-limit-cpu"`
-	ResourcesLimitMemory      *string            `yaml:"resources-limit-memory"`
-	ResourcesRequestCPU       *string            `yaml:"resources-request-cpu"`
-	ResourcesRequestMemory    *string            `yaml:"resources-request-memory"`
-	RestartPolicy             *string            `yaml:"restart-policy"`
-	UpdateStrategy            *string            `yaml:"update-strategy"`
+	Affinity                     *pod.Affinity                  `yaml:"affinity"`
+	Annotations                  *map[string]string             `yaml:"annotations"`
+	ClefImage                    *string                        `yaml:"clef-image"`
+	ClefImagePullPolicy          *string                        `yaml:"clef-image-pull-policy"`
+	Image                        *string                        `yaml:"image"`
+	ImagePullPolicy              *string                        `yaml:"image-pull-policy"`
+	ImagePullSecrets             *[]string                      `yaml:"image-pull-secrets"`
+	IngressAnnotations           *map[string]string             `yaml:"ingress-annotations"`
+	IngressClass                 *string                        `yaml:"ingress-class"`
+	IngressTLSClusterIssuer      *string                        `yaml:"ingress-tls-cluster-issuer"`
+	IngressTLSSecret             *string                        `yaml:"ingress-tls-secret"`
+	IngressDebugAnnotations      *map[string]string             `yaml:"ingress-debug-annotations"`
+	IngressDebugClass            *string                        `yaml:"ingress-debug-class"`
+	IngressDebugTLSClusterIssuer *string                        `yaml:"ingress-debug-tls-cluster-issuer"`
+	IngressDebugTLSSecret        *string                        `yaml:"ingress-debug-tls-secret"`
+	Labels                       *map[string]string             `yaml:"labels"`
+	NodeSelector                 *map[string]string             `yaml:"node-selector"`
+	PersistenceEnabled           *bool                          `yaml:"persistence-enabled"`
+	PersistenceStorageClass      *string                        `yaml:"persistence-storage-class"`
+	PersistenceStorageRequest    *string                        `yaml:"persistence-storage-request"`
+	PodManagementPolicy          *string                        `yaml:"pod-management-policy"`
+	PriorityClassName            *string                        `yaml:"priority-class-name"`
+	ResourcesLimitCPU            *string                        `yaml:"resources-limit-cpu"`
+	ResourcesLimitMemory         *string                        `yaml:"resources-limit-memory"`
+	ResourcesRequestCPU          *string                        `yaml:"resources-request-cpu"`
+	ResourcesRequestMemory       *string                        `yaml:"resources-request-memory"`
+	RestartPolicy                *string                        `yaml:"restart-policy"`
+	Tolerations                  *pod.Tolerations               `yaml:"tolerations"`
+	TopologySpreadConstraints    *pod.TopologySpreadConstraints `yaml:"topology-spread-constraints"`
+	UpdateStrategy               *string                        `yaml:"update-strategy"`
 }
 
 // Export exports NodeGroup to bee.NodeGroupOptions
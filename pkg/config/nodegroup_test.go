@@ -0,0 +1,112 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
+	"gopkg.in/yaml.v3"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeGroupSchedulingPodSpec(t *testing.T) {
+	const config = `
+affinity:
+  node-affinity:
+    required-during-scheduling-ignored-during-execution:
+      node-selector-terms:
+        - match-expressions:
+            - key: node-role
+              operator: In
+              values: ["bee"]
+  pod-anti-affinity:
+    preferred-during-scheduling-ignored-during-execution:
+      - weight: 100
+        pod-affinity-term:
+          label-selector:
+            app.kubernetes.io/name: bee
+          topology-key: kubernetes.io/hostname
+priority-class-name: bee-high
+tolerations:
+  - key: dedicated
+    operator: Equal
+    value: bee
+    effect: NoSchedule
+  - key: node.kubernetes.io/unreachable
+    operator: Exists
+    effect: NoExecute
+    toleration-seconds: 30
+topology-spread-constraints:
+  - max-skew: 1
+    topology-key: topology.kubernetes.io/zone
+    when-unsatisfiable: ScheduleAnyway
+    label-selector:
+      app.kubernetes.io/name: bee
+`
+
+	var ng NodeGroup
+	if err := yaml.Unmarshal([]byte(config), &ng); err != nil {
+		t.Fatal(err)
+	}
+	o := ng.Export()
+
+	// pod spec is set from node group options the same way as in the bee
+	// statefulset
+	spec := (&pod.PodTemplateSpec{Spec: pod.PodSpec{
+		Affinity:                  o.Affinity,
+		PriorityClassName:         o.PriorityClassName,
+		Tolerations:               o.Tolerations,
+		TopologySpreadConstraints: o.TopologySpreadConstraints,
+	}}).ToK8S().Spec
+
+	labels := &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "bee"}}
+	wantAffinity := &v1.Affinity{
+		NodeAffinity: &v1.NodeAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []v1.PreferredSchedulingTerm{},
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+				NodeSelectorTerms: []v1.NodeSelectorTerm{{
+					MatchExpressions: []v1.NodeSelectorRequirement{{Key: "node-role", Operator: v1.NodeSelectorOpIn, Values: []string{"bee"}}},
+					MatchFields:      []v1.NodeSelectorRequirement{},
+				}},
+			},
+		},
+		PodAntiAffinity: &v1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{{
+				Weight:          100,
+				PodAffinityTerm: v1.PodAffinityTerm{LabelSelector: labels, TopologyKey: "kubernetes.io/hostname"},
+			}},
+			RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{},
+		},
+	}
+	if !reflect.DeepEqual(spec.Affinity, wantAffinity) {
+		t.Errorf("got affinity %+v, want %+v", spec.Affinity, wantAffinity)
+	}
+
+	seconds := int64(30)
+	wantTolerations := []v1.Toleration{
+		{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "bee", Effect: v1.TaintEffectNoSchedule},
+		{Key: "node.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: &seconds},
+	}
+	if !reflect.DeepEqual(spec.Tolerations, wantTolerations) {
+		t.Errorf("got tolerations %+v, want %+v", spec.Tolerations, wantTolerations)
+	}
+
+	wantTopology := []v1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: v1.ScheduleAnyway,
+		LabelSelector:     labels,
+	}}
+	if !reflect.DeepEqual(spec.TopologySpreadConstraints, wantTopology) {
+		t.Errorf("got topology spread constraints %+v, want %+v", spec.TopologySpreadConstraints, wantTopology)
+	}
+
+	if spec.PriorityClassName != "bee-high" {
+		t.Errorf("got priority class %q, want bee-high", spec.PriorityClassName)
+	}
+	// priority is resolved from the class by admission, it must not be set together with it
+	if spec.Priority != nil {
+		t.Errorf("got priority %d set with priority class", *spec.Priority)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
 )
 
 // ErrNotSet represents error when Kubernetes Bee client is not set
@@ -14,7 +16,10 @@ type Bee interface {
 	Create(ctx context.Context, o CreateOptions) (err error)
 	Delete(ctx context.Context, name, namespace string) (err error)
 	Ready(ctx context.Context, name, namespace string) (ready bool, err error)
+	Restore(ctx context.Context, name, namespace string, o RestoreOptions) (err error)
 	RunningNodes(ctx context.Context, namespace string) (running []string, err error)
+	Snapshot(ctx context.Context, name, namespace string, o SnapshotOptions) (err error)
+	SnapshotReady(ctx context.Context, name, namespace, snapshot string) (ready bool, err error)
 	Start(ctx context.Context, name, namespace string) (err error)
 	Stop(ctx context.Context, name, namespace string) (err error)
 	StoppedNodes(ctx context.Context, namespace string) (stopped []string, err error)
@@ -25,82 +30,108 @@ type CreateOptions struct {
 	// Bee configuration
 	Config Config
 	// Kubernetes configuration
-	Name                      string
-	Namespace                 string
+	Name                         string
+	Namespace                    string
+	Affinity                     pod.Affinity
+	Annotations                  map[string]string
+	ClefImage                    string
+	ClefImagePullPolicy          string
+	ClefKey                      string
+	ClefPassword                 string
+	Labels                       map[string]string
+	Image                        string
+	ImagePullPolicy              string
+	ImagePullSecrets             []string
+	IngressAnnotations           map[string]string
+	IngressClass                 string
+	IngressHost                  string
+	IngressTLSClusterIssuer      string
+	IngressTLSSecret             string
+	IngressDebugAnnotations      map[string]string
+	IngressDebugClass            string
+	IngressDebugHost             string
+	IngressDebugTLSClusterIssuer string
+	IngressDebugTLSSecret        string
+	LibP2PKey                    string
+	NodeSelector                 map[string]string
+	PersistenceEnabled           bool
+	PersistenceStorageClass      string
+	PersistenceStorageRequest    string
+	PodManagementPolicy          string
+	PriorityClassName            string
+	RestartPolicy                string
+	ResourcesLimitCPU            string
+	ResourcesLimitMemory         string
+	ResourcesRequestCPU          string
+	ResourcesRequestMemory       string
+	Selector                     map[string]string
+	SwarmKey                     string
+	Tolerations                  pod.Tolerations
+	TopologySpreadConstraints    pod.TopologySpreadConstraints
+	UpdateStrategy               string
+}
+
+// SnapshotOptions represents available options for creating node's snapshot
+type SnapshotOptions struct {
+	Snapshot            string
+	Annotations         map[string]string
+	Labels              map[string]string
+	VolumeSnapshotClass string
+}
+
+// RestoreOptions represents available options for restoring node from the snapshot
+type RestoreOptions struct {
+	Snapshot                  string
 	Annotations               map[string]string
-	ClefImage                 string
-	ClefImagePullPolicy       string
-	ClefKey                   string
-	ClefPassword              string
 	Labels                    map[string]string
-	Image                     string
-	ImagePullPolicy           string
-	ImagePullSecrets          []string
-	IngressAnnotations        map[string]string
-	IngressClass              string
-	IngressHost               string
-	IngressDebugAnnotations   map[string]string
-	IngressDebugClass         string
-	IngressDebugHost          string
-	LibP2PKey                 string
-	NodeSelector              map[string]string
-	PersistenceEnabled        bool
 	PersistenceStorageClass   string
 	PersistenceStorageRequest string
-	PodManagementPolicy       string
-	RestartPolicy             string
-	ResourcesLimitCPU         string
-	ResourcesLimitMemory      string
-	ResourcesRequestCPU       string
-	ResourcesRequestMemory    string
-	Selector                  map[string]string
-	SwarmKey                  string
-	UpdateStrategy            string
 }
 
 // Config represents Bee configuration
 type Config struct {
-	APIAddr                    string        // HTTP API listen address
-	BlockTime                  uint64        // chain block time
-	Bootnodes                  string        // initial nodes to connect to
-	BootnodeMode               bool          // cause the node to always accept incoming connections
-	CacheCapacity              uint64        // cache capacity in chunks, multiply by 4096 (MaxChunkSize) to get approximate capacity in bytes
-	ClefSignerEnable           bool          // enable clef signer
-	ClefSignerEndpoint         string        // clef signer endpoint
-	CORSAllowedOrigins         string        // origins with CORS headers enabled
-	DataDir                    string        // data directory
-	DbOpenFilesLimit           int           // number of open files allowed by database
-	DbBlockCacheCapacity       int           // size of block cache of the database in bytes
-	DbWriteBufferSize          int           // size of the database write buffer in bytes
-	DbDisableSeeksCompaction   bool          // disables DB compactions triggered by seeks
-	DebugAPIAddr               string        // debug HTTP API listen address
-	DebugAPIEnable             bool          // enable debug HTTP API
-	FullNode                   bool          // cause the node to start in full mode
-	GatewayMode                bool          // disable a set of sensitive features in the api
-	GlobalPinningEnabled       bool          // enable global pinning
-	NATAddr                    string        // NAT exposed address
-	NetworkID                  uint64        // ID of the Swarm network
-	P2PAddr                    string        // P2P listen address
-	P2PQUICEnable              bool          // enable P2P QUIC transport
-	P2PWSEnable                bool          // enable P2P WebSocket transport
-	Password                   string        // password for decrypting keys
-	PaymentEarly               uint64        // amount in BZZ below the peers payment threshold when we initiate settlement
-	PaymentThreshold           uint64        // threshold in BZZ where you expect to get paid from your peers
-	PaymentTolerance           uint64        // excess debt above payment threshold in BZZ where you disconnect from your peer
-	PostageStampAddress        string        // postage stamp address
-	PriceOracleAddress         string        // price Oracle address
-	ResolverOptions            string        // ENS compatible API endpoint for a TLD and with contract address, can be repeated, format [tld:][contract-addr@]url
-	Standalone                 bool          // whether we want the node to start with no listen addresses for p2p
-	SwapEnable                 bool          // enable swap
-	SwapEndpoint               string        // swap ethereum blockchain endpoint
-	SwapDeploymentGasPrice     string        // gas price in wei to use for deployment and funding
-	SwapFactoryAddress         string        // swap factory address
-	SwapLegacyFactoryAddresses string        // swap legacy factory addresses
-	SwapInitialDeposit         uint64        // initial deposit if deploying a new chequebook
-	TracingEnabled             bool          // enable tracing
-	TracingEndpoint            string        // endpoint to send tracing data
-	TracingServiceName         string        // service name identifier for tracing
-	Verbosity                  uint64        // log verbosity level 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=trace
-	WelcomeMessage             string        // send a welcome message string during handshakes
-	WarmupTime                 time.Duration // warmup time pull/pushsync protocols
+	APIAddr                    string                 `yaml:"api-addr"`                      // HTTP API listen address
+	BlockTime                  uint64                 `yaml:"block-time"`                    // chain block time
+	Bootnodes                  string                 `yaml:"bootnode"`                      // initial nodes to connect to
+	BootnodeMode               bool                   `yaml:"bootnode-mode"`                 // cause the node to always accept incoming connections
+	CacheCapacity              uint64                 `yaml:"cache-capacity"`                // cache capacity in chunks, multiply by 4096 (MaxChunkSize) to get approximate capacity in bytes
+	ClefSignerEnable           bool                   `yaml:"clef-signer-enable"`            // enable clef signer
+	ClefSignerEndpoint         string                 `yaml:"clef-signer-endpoint"`          // clef signer endpoint
+	CORSAllowedOrigins         string                 `yaml:"cors-allowed-origins"`          // origins with CORS headers enabled
+	DataDir                    string                 `yaml:"data-dir"`                      // data directory
+	DbOpenFilesLimit           int                    `yaml:"db-open-files-limit"`           // number of open files allowed by database
+	DbBlockCacheCapacity       int                    `yaml:"db-block-cache-capacity"`       // size of block cache of the database in bytes
+	DbWriteBufferSize          int                    `yaml:"db-write-buffer-size"`          // size of the database write buffer in bytes
+	DbDisableSeeksCompaction   bool                   `yaml:"db-disable-seeks-compaction"`   // disables DB compactions triggered by seeks
+	DebugAPIAddr               string                 `yaml:"debug-api-addr"`                // debug HTTP API listen address
+	DebugAPIEnable             bool                   `yaml:"debug-api-enable"`              // enable debug HTTP API
+	FullNode                   bool                   `yaml:"full-node"`                     // cause the node to start in full mode
+	GatewayMode                bool                   `yaml:"gateway-mode"`                  // disable a set of sensitive features in the api
+	GlobalPinningEnabled       bool                   `yaml:"global-pinning-enable"`         // enable global pinning
+	NATAddr                    string                 `yaml:"nat-addr"`                      // NAT exposed address
+	NetworkID                  uint64                 `yaml:"network-id"`                    // ID of the Swarm network
+	P2PAddr                    string                 `yaml:"p2p-addr"`                      // P2P listen address
+	P2PQUICEnable              bool                   `yaml:"p2p-quic-enable"`               // enable P2P QUIC transport
+	P2PWSEnable                bool                   `yaml:"p2p-ws-enable"`                 // enable P2P WebSocket transport
+	Password                   string                 `yaml:"password"`                      // password for decrypting keys
+	PaymentEarly               uint64                 `yaml:"payment-early"`                 // amount in BZZ below the peers payment threshold when we initiate settlement
+	PaymentThreshold           uint64                 `yaml:"payment-threshold"`             // threshold in BZZ where you expect to get paid from your peers
+	PaymentTolerance           uint64                 `yaml:"payment-tolerance"`             // excess debt above payment threshold in BZZ where you disconnect from your peer
+	PostageStampAddress        string                 `yaml:"postage-stamp-address"`         // postage stamp address
+	PriceOracleAddress         string                 `yaml:"price-oracle-address"`          // price Oracle address
+	ResolverOptions            string                 `yaml:"resolver-options"`              // ENS compatible API endpoint for a TLD and with contract address, can be repeated, format [tld:][contract-addr@]url
+	Standalone                 bool                   `yaml:"standalone"`                    // whether we want the node to start with no listen addresses for p2p
+	SwapEnable                 bool                   `yaml:"swap-enable"`                   // enable swap
+	SwapEndpoint               string                 `yaml:"swap-endpoint"`                 // swap ethereum blockchain endpoint
+	SwapDeploymentGasPrice     string                 `yaml:"swap-deployment-gas-price"`     // gas price in wei to use for deployment and funding
+	SwapFactoryAddress         string                 `yaml:"swap-factory-address"`          // swap factory address
+	SwapLegacyFactoryAddresses string                 `yaml:"swap-legacy-factory-addresses"` // swap legacy factory addresses
+	SwapInitialDeposit         uint64                 `yaml:"swap-initial-deposit"`          // initial deposit if deploying a new chequebook
+	TracingEnabled             bool                   `yaml:"tracing-enable"`                // enable tracing
+	TracingEndpoint            string                 `yaml:"tracing-endpoint"`              // endpoint to send tracing data
+	TracingServiceName         string                 `yaml:"tracing-service-name"`          // service name identifier for tracing
+	Verbosity                  uint64                 `yaml:"verbosity"`                     // log verbosity level 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=trace
+	WelcomeMessage             string                 `yaml:"welcome-message"`               // send a welcome message string during handshakes
+	WarmupTime                 time.Duration          `yaml:"warmup-time"`                   // warmup time pull/pushsync protocols
+	Extra                      map[string]interface{} `yaml:"-"`                             // options not listed above, they override listed ones
 }
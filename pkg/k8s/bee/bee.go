@@ -1,21 +1,25 @@
 package bee
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"html/template"
 
 	"github.com/ethersphere/beekeeper/pkg/k8s"
 	"github.com/ethersphere/beekeeper/pkg/k8s/configmap"
 	"github.com/ethersphere/beekeeper/pkg/k8s/ingress"
+	pvc "github.com/ethersphere/beekeeper/pkg/k8s/persistentvolumeclaim"
 	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
 	"github.com/ethersphere/beekeeper/pkg/k8s/secret"
 	"github.com/ethersphere/beekeeper/pkg/k8s/service"
 	"github.com/ethersphere/beekeeper/pkg/k8s/serviceaccount"
 	"github.com/ethersphere/beekeeper/pkg/k8s/statefulset"
+	"github.com/ethersphere/beekeeper/pkg/k8s/volumesnapshot"
+	"k8s.io/apimachinery/pkg/api/errors"
 )
 
+// snapshotLabel is set on all objects that belong to the snapshot
+const snapshotLabel = "beekeeper.ethswarm.org/snapshot"
+
 // compile check whether client implements interface
 var _ k8s.Bee = (*Client)(nil)
 
@@ -39,9 +43,9 @@ func NewClient(k8s *k8s.Client) (c *Client) {
 // Create creates Bee node in the cluster
 func (c *Client) Create(ctx context.Context, o k8s.CreateOptions) (err error) {
 	// bee configuration
-	var config bytes.Buffer
-	if err := template.Must(template.New("").Parse(configTemplate)).Execute(&config, o.Config); err != nil {
-		return err
+	config, err := setBeeConfig(o.Config)
+	if err != nil {
+		return fmt.Errorf("render bee configuration: %w", err)
 	}
 
 	configCM := o.Name
@@ -49,7 +53,7 @@ func (c *Client) Create(ctx context.Context, o k8s.CreateOptions) (err error) {
 		Annotations: o.Annotations,
 		Labels:      o.Labels,
 		Data: map[string]string{
-			".bee.yaml": config.String(),
+			".bee.yaml": config,
 		},
 	}); err != nil {
 		return fmt.Errorf("set configmap in namespace %s: %w", o.Namespace, err)
@@ -135,10 +139,16 @@ func (c *Client) Create(ctx context.Context, o k8s.CreateOptions) (err error) {
 	// api service's ingress
 	apiIn := fmt.Sprintf("%s-api", o.Name)
 	if err := c.k8s.Ingress.Set(ctx, apiIn, o.Namespace, ingress.Options{
-		Annotations: mergeMaps(o.Annotations, o.IngressAnnotations),
+		Annotations: mergeMaps(mergeMaps(o.Annotations, o.IngressAnnotations), setIngressTLSAnnotations(o.IngressTLSClusterIssuer)),
 		Labels:      o.Labels,
 		Spec: ingress.Spec{
 			Class: o.IngressClass,
+			TLS: setIngressTLS(setIngressTLSOptions{
+				Name:          apiIn,
+				Host:          o.IngressHost,
+				ClusterIssuer: o.IngressTLSClusterIssuer,
+				Secret:        o.IngressTLSSecret,
+			}),
 			Rules: ingress.Rules{{
 				Host: o.IngressHost,
 				Paths: ingress.Paths{{
@@ -186,10 +196,16 @@ func (c *Client) Create(ctx context.Context, o k8s.CreateOptions) (err error) {
 	// debug service's ingress
 	debugIn := fmt.Sprintf("%s-debug", o.Name)
 	if err := c.k8s.Ingress.Set(ctx, debugIn, o.Namespace, ingress.Options{
-		Annotations: mergeMaps(o.Annotations, o.IngressDebugAnnotations),
+		Annotations: mergeMaps(mergeMaps(o.Annotations, o.IngressDebugAnnotations), setIngressTLSAnnotations(o.IngressDebugTLSClusterIssuer)),
 		Labels:      o.Labels,
 		Spec: ingress.Spec{
 			Class: o.IngressDebugClass,
+			TLS: setIngressTLS(setIngressTLSOptions{
+				Name:          debugIn,
+				Host:          o.IngressDebugHost,
+				ClusterIssuer: o.IngressDebugTLSClusterIssuer,
+				Secret:        o.IngressDebugTLSSecret,
+			}),
 			Rules: ingress.Rules{{
 				Host: o.IngressDebugHost,
 				Paths: ingress.Paths{{
@@ -300,6 +316,7 @@ func (c *Client) Create(ctx context.Context, o k8s.CreateOptions) (err error) {
 				Annotations: o.Annotations,
 				Labels:      o.Labels,
 				Spec: pod.PodSpec{
+					Affinity: o.Affinity,
 					InitContainers: setInitContainers(setInitContainersOptions{
 						ClefEnabled:         clefEnabled,
 						ClefSecretEnabled:   clefSecretEnabled,
@@ -333,8 +350,11 @@ func (c *Client) Create(ctx context.Context, o k8s.CreateOptions) (err error) {
 					PodSecurityContext: pod.PodSecurityContext{
 						FSGroup: 999,
 					},
-					RestartPolicy:      o.RestartPolicy,
-					ServiceAccountName: svcAccount,
+					PriorityClassName:         o.PriorityClassName,
+					RestartPolicy:             o.RestartPolicy,
+					ServiceAccountName:        svcAccount,
+					Tolerations:               o.Tolerations,
+					TopologySpreadConstraints: o.TopologySpreadConstraints,
 					Volumes: setVolumes(setVolumesOptions{
 						ConfigCM:           configCM,
 						KeysSecret:         keysSecret,
@@ -457,6 +477,66 @@ func (c *Client) Ready(ctx context.Context, name, namespace string) (ready bool,
 	return r == 1, nil
 }
 
+// Restore restores Bee node's data volume, keys and configuration from the snapshot.
+// It must be called after node is created and before it is started for the
+// first time, so that its PersistentVolumeClaim is not yet provisioned.
+func (c *Client) Restore(ctx context.Context, name, namespace string, o k8s.RestoreOptions) (err error) {
+	snapshot := fmt.Sprintf("%s-%s", o.Snapshot, name)
+
+	// bee configuration
+	configCM := name
+	cm, err := c.k8s.ConfigMap.Get(ctx, snapshot, namespace)
+	if err != nil {
+		return fmt.Errorf("get configmap in namespace %s: %w", namespace, err)
+	}
+	if err = c.k8s.ConfigMap.Set(ctx, configCM, namespace, configmap.Options{
+		Annotations: o.Annotations,
+		Labels:      o.Labels,
+		Data:        cm.Data,
+		BinaryData:  cm.BinaryData,
+	}); err != nil {
+		return fmt.Errorf("set configmap in namespace %s: %w", namespace, err)
+	}
+	fmt.Printf("configmap %s is restored from %s in namespace %s\n", configCM, snapshot, namespace)
+
+	// secrets with keys
+	for _, s := range []string{"keys", "clef"} {
+		nodeSecret := fmt.Sprintf("%s-%s", name, s)
+		snapshotSecret := fmt.Sprintf("%s-%s", snapshot, s)
+		restored, err := c.copySecret(ctx, snapshotSecret, nodeSecret, namespace, o.Annotations, o.Labels)
+		if err != nil {
+			return err
+		}
+		if restored {
+			fmt.Printf("secret %s is restored from %s in namespace %s\n", nodeSecret, snapshotSecret, namespace)
+		}
+	}
+
+	// data volume
+	dataPVC := fmt.Sprintf("data-%s-0", name)
+	if err := c.k8s.PVC.Set(ctx, dataPVC, namespace, pvc.Options{
+		Annotations: o.Annotations,
+		Labels:      o.Labels,
+		Spec: pvc.PersistentVolumeClaimSpec{
+			AccessModes: pvc.AccessModes{
+				pvc.AccessMode("ReadWriteOnce"),
+			},
+			DataSource: pvc.DataSource{
+				APIGroup: volumesnapshot.APIGroup,
+				Kind:     "VolumeSnapshot",
+				Name:     snapshot,
+			},
+			RequestStorage: o.PersistenceStorageRequest,
+			StorageClass:   o.PersistenceStorageClass,
+		},
+	}); err != nil {
+		return fmt.Errorf("set pvc in namespace %s: %w", namespace, err)
+	}
+	fmt.Printf("pvc %s is restored from volumesnapshot %s in namespace %s\n", dataPVC, snapshot, namespace)
+
+	return
+}
+
 // RunningNodes returns list of running nodes
 // TODO: filter by labels
 func (c *Client) RunningNodes(ctx context.Context, namespace string) (running []string, err error) {
@@ -467,6 +547,62 @@ func (c *Client) RunningNodes(ctx context.Context, namespace string) (running []
 	return
 }
 
+// Snapshot creates snapshot of Bee node's data volume, keys and configuration
+func (c *Client) Snapshot(ctx context.Context, name, namespace string, o k8s.SnapshotOptions) (err error) {
+	snapshot := fmt.Sprintf("%s-%s", o.Snapshot, name)
+	labels := mergeMaps(o.Labels, map[string]string{
+		snapshotLabel: o.Snapshot,
+	})
+
+	// bee configuration
+	configCM := name
+	cm, err := c.k8s.ConfigMap.Get(ctx, configCM, namespace)
+	if err != nil {
+		return fmt.Errorf("get configmap in namespace %s: %w", namespace, err)
+	}
+	if err = c.k8s.ConfigMap.Set(ctx, snapshot, namespace, configmap.Options{
+		Annotations: o.Annotations,
+		Labels:      labels,
+		Data:        cm.Data,
+		BinaryData:  cm.BinaryData,
+	}); err != nil {
+		return fmt.Errorf("set configmap in namespace %s: %w", namespace, err)
+	}
+	fmt.Printf("configmap %s is saved to %s in namespace %s\n", configCM, snapshot, namespace)
+
+	// secrets with keys
+	for _, s := range []string{"keys", "clef"} {
+		nodeSecret := fmt.Sprintf("%s-%s", name, s)
+		snapshotSecret := fmt.Sprintf("%s-%s", snapshot, s)
+		saved, err := c.copySecret(ctx, nodeSecret, snapshotSecret, namespace, o.Annotations, labels)
+		if err != nil {
+			return err
+		}
+		if saved {
+			fmt.Printf("secret %s is saved to %s in namespace %s\n", nodeSecret, snapshotSecret, namespace)
+		}
+	}
+
+	// data volume
+	dataPVC := fmt.Sprintf("data-%s-0", name)
+	if err := c.k8s.VolumeSnapshot.Create(ctx, snapshot, namespace, volumesnapshot.Options{
+		Annotations:           o.Annotations,
+		Labels:                labels,
+		PersistentVolumeClaim: dataPVC,
+		VolumeSnapshotClass:   o.VolumeSnapshotClass,
+	}); err != nil {
+		return fmt.Errorf("create volumesnapshot in namespace %s: %w", namespace, err)
+	}
+	fmt.Printf("volumesnapshot %s of pvc %s is created in namespace %s\n", snapshot, dataPVC, namespace)
+
+	return
+}
+
+// SnapshotReady gets Bee node's snapshot readiness
+func (c *Client) SnapshotReady(ctx context.Context, name, namespace, snapshot string) (ready bool, err error) {
+	return c.k8s.VolumeSnapshot.ReadyToUse(ctx, fmt.Sprintf("%s-%s", snapshot, name), namespace)
+}
+
 // Start starts Bee node in the cluster
 func (c *Client) Start(ctx context.Context, name, namespace string) (err error) {
 	err = c.k8s.StatefulSet.Scale(ctx, name, namespace, 1)
@@ -498,3 +634,25 @@ func (c *Client) StoppedNodes(ctx context.Context, namespace string) (stopped []
 	}
 	return
 }
+
+// copySecret copies Secret's data to the new Secret, it returns false if source Secret does not exist
+func (c *Client) copySecret(ctx context.Context, from, to, namespace string, annotations, labels map[string]string) (ok bool, err error) {
+	s, err := c.k8s.Secret.Get(ctx, from, namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("get secret in namespace %s: %w", namespace, err)
+	}
+
+	if err := c.k8s.Secret.Set(ctx, to, namespace, secret.Options{
+		Annotations: annotations,
+		Labels:      labels,
+		Data:        s.Data,
+		Type:        string(s.Type),
+	}); err != nil {
+		return false, fmt.Errorf("set secret in namespace %s: %w", namespace, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,170 @@
+package bee
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s"
+	"github.com/ethersphere/beekeeper/pkg/k8s/configmap"
+	pvc "github.com/ethersphere/beekeeper/pkg/k8s/persistentvolumeclaim"
+	"github.com/ethersphere/beekeeper/pkg/k8s/secret"
+	"github.com/ethersphere/beekeeper/pkg/k8s/volumesnapshot"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "test"
+
+var volumeSnapshots = schema.GroupVersionResource{Group: volumesnapshot.APIGroup, Version: "v1", Resource: "volumesnapshots"}
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	cs := fake.NewSimpleClientset(
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "bee-0", Namespace: testNamespace},
+			Data:       map[string]string{".bee.yaml": "api-addr: :1633\n"},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "bee-0-keys", Namespace: testNamespace},
+			Data:       map[string][]byte{"swarm": []byte("swarm key")},
+			Type:       v1.SecretTypeOpaque,
+		},
+	)
+	dc := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{volumeSnapshots: "VolumeSnapshotList"})
+	c := newTestClient(cs, dc)
+
+	if err := c.Snapshot(ctx, "bee-0", testNamespace, k8s.SnapshotOptions{
+		Snapshot:            "snap",
+		Labels:              map[string]string{"app": "bee"},
+		VolumeSnapshotClass: "csi-snapclass",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	wantLabels := map[string]string{"app": "bee", snapshotLabel: "snap"}
+
+	cm, err := cs.CoreV1().ConfigMaps(testNamespace).Get(ctx, "snap-bee-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm.Data[".bee.yaml"] != "api-addr: :1633\n" || !reflect.DeepEqual(cm.Labels, wantLabels) {
+		t.Fatalf("got configmap data %v and labels %v", cm.Data, cm.Labels)
+	}
+
+	s, err := cs.CoreV1().Secrets(testNamespace).Get(ctx, "snap-bee-0-keys", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(s.Data["swarm"]) != "swarm key" || s.Type != v1.SecretTypeOpaque || !reflect.DeepEqual(s.Labels, wantLabels) {
+		t.Fatalf("got secret data %v, type %s and labels %v", s.Data, s.Type, s.Labels)
+	}
+	// node without clef has no clef secret to save
+	if _, err := cs.CoreV1().Secrets(testNamespace).Get(ctx, "snap-bee-0-clef", metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Fatalf("got clef secret: %v", err)
+	}
+
+	vs, err := dc.Resource(volumeSnapshots).Namespace(testNamespace).Get(ctx, "snap-bee-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim, _, _ := unstructured.NestedString(vs.Object, "spec", "source", "persistentVolumeClaimName")
+	class, _, _ := unstructured.NestedString(vs.Object, "spec", "volumeSnapshotClassName")
+	if claim != "data-bee-0-0" || class != "csi-snapclass" || !reflect.DeepEqual(vs.GetLabels(), wantLabels) {
+		t.Fatalf("got volumesnapshot of pvc %s with class %s and labels %v", claim, class, vs.GetLabels())
+	}
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	cs := fake.NewSimpleClientset(
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "snap-bee-0", Namespace: testNamespace},
+			Data:       map[string]string{".bee.yaml": "api-addr: :1633\n"},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "snap-bee-0-keys", Namespace: testNamespace},
+			Data:       map[string][]byte{"swarm": []byte("swarm key")},
+			Type:       v1.SecretTypeOpaque,
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "snap-bee-0-clef", Namespace: testNamespace},
+			Data:       map[string][]byte{"key": []byte("clef key")},
+			Type:       v1.SecretTypeOpaque,
+		},
+	)
+	c := newTestClient(cs, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	labels := map[string]string{"app": "bee"}
+	if err := c.Restore(ctx, "bee-0", testNamespace, k8s.RestoreOptions{
+		Snapshot:                  "snap",
+		Labels:                    labels,
+		PersistenceStorageClass:   "standard",
+		PersistenceStorageRequest: "1Gi",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	cm, err := cs.CoreV1().ConfigMaps(testNamespace).Get(ctx, "bee-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm.Data[".bee.yaml"] != "api-addr: :1633\n" || !reflect.DeepEqual(cm.Labels, labels) {
+		t.Fatalf("got configmap data %v and labels %v", cm.Data, cm.Labels)
+	}
+
+	for name, want := range map[string]string{"bee-0-keys": "swarm key", "bee-0-clef": "clef key"} {
+		s, err := cs.CoreV1().Secrets(testNamespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range s.Data {
+			if string(v) != want {
+				t.Fatalf("got secret %s data %q, want %q", name, v, want)
+			}
+		}
+	}
+
+	claim, err := cs.CoreV1().PersistentVolumeClaims(testNamespace).Get(ctx, "data-bee-0-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ds := claim.Spec.DataSource
+	if ds == nil || ds.APIGroup == nil || *ds.APIGroup != volumesnapshot.APIGroup || ds.Kind != "VolumeSnapshot" || ds.Name != "snap-bee-0" {
+		t.Fatalf("got pvc data source %+v", ds)
+	}
+	if claim.Spec.StorageClassName == nil || *claim.Spec.StorageClassName != "standard" {
+		t.Fatalf("got pvc storage class %v, want standard", claim.Spec.StorageClassName)
+	}
+	if got := claim.Spec.Resources.Requests[v1.ResourceStorage]; got.Cmp(resource.MustParse("1Gi")) != 0 {
+		t.Fatalf("got pvc storage request %s, want 1Gi", got.String())
+	}
+	if !reflect.DeepEqual(claim.Labels, labels) {
+		t.Fatalf("got pvc labels %v, want %v", claim.Labels, labels)
+	}
+}
+
+func TestRestoreMissingSnapshot(t *testing.T) {
+	c := newTestClient(fake.NewSimpleClientset(), dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()))
+
+	if err := c.Restore(context.Background(), "bee-0", testNamespace, k8s.RestoreOptions{Snapshot: "snap"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+// newTestClient returns client with Kubernetes services used by snapshots
+func newTestClient(cs kubernetes.Interface, dc *dynamicfake.FakeDynamicClient) *Client {
+	return NewClient(&k8s.Client{
+		ConfigMap:      configmap.NewClient(cs),
+		PVC:            pvc.NewClient(cs),
+		Secret:         secret.NewClient(cs),
+		VolumeSnapshot: volumesnapshot.NewClient(dc),
+	})
+}
@@ -1,61 +1,51 @@
 package bee
 
 import (
+	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/ethersphere/beekeeper/pkg/k8s"
 	"github.com/ethersphere/beekeeper/pkg/k8s/containers"
+	"github.com/ethersphere/beekeeper/pkg/k8s/ingress"
 	pvc "github.com/ethersphere/beekeeper/pkg/k8s/persistentvolumeclaim"
 	"github.com/ethersphere/beekeeper/pkg/k8s/pod"
 	"github.com/ethersphere/beekeeper/pkg/k8s/service"
+	"gopkg.in/yaml.v3"
 )
 
-const (
-	configTemplate = `api-addr: {{.APIAddr}}
-block-time: {{ .BlockTime }}
-bootnode: {{.Bootnodes}}
-bootnode-mode: {{.BootnodeMode}}
-cache-capacity: {{.CacheCapacity}}
-clef-signer-enable: {{.ClefSignerEnable}}
-clef-signer-endpoint: {{.ClefSignerEndpoint}}
-cors-allowed-origins: {{.CORSAllowedOrigins}}
-data-dir: {{.DataDir}}
-db-open-files-limit: {{.DbOpenFilesLimit}}
-db-block-cache-capacity: {{.DbBlockCacheCapacity}}
-db-write-buffer-size: {{.DbWriteBufferSize}}
-db-disable-seeks-compaction: {{.DbDisableSeeksCompaction}}
-debug-api-addr: {{.DebugAPIAddr}}
-debug-api-enable: {{.DebugAPIEnable}}
-full-node: {{.FullNode}}
-gateway-mode: {{.GatewayMode}}
-global-pinning-enable: {{.GlobalPinningEnabled}}
-nat-addr: {{.NATAddr}}
-network-id: {{.NetworkID}}
-p2p-addr: {{.P2PAddr}}
-p2p-quic-enable: {{.P2PQUICEnable}}
-p2p-ws-enable: {{.P2PWSEnable}}
-password: {{.Password}}
-payment-early: {{.PaymentEarly}}
-payment-threshold: {{.PaymentThreshold}}
-payment-tolerance: {{.PaymentTolerance}}
-postage-stamp-address: {{ .PostageStampAddress }}
-price-oracle-address: {{ .PriceOracleAddress }}
-resolver-options: {{.ResolverOptions}}
-standalone: {{.Standalone}}
-swap-enable: {{.SwapEnable}}
-swap-endpoint: {{.SwapEndpoint}}
-swap-deployment-gas-price: {{.SwapDeploymentGasPrice}}
-swap-factory-address: {{.SwapFactoryAddress}}
-swap-legacy-factory-addresses: {{.SwapLegacyFactoryAddresses}}
-swap-initial-deposit: {{.SwapInitialDeposit}}
-tracing-enable: {{.TracingEnabled}}
-tracing-endpoint: {{.TracingEndpoint}}
-tracing-service-name: {{.TracingServiceName}}
-verbosity: {{.Verbosity}}
-welcome-message: {{.WelcomeMessage}}
-warmup-time: {{.WarmupTime}}
-`
-)
+// setBeeConfig renders Bee configuration file, options from Extra override the typed ones
+func setBeeConfig(c k8s.Config) (string, error) {
+	options := make(map[string]interface{})
+
+	v := reflect.ValueOf(c)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("yaml")
+		if len(name) == 0 || name == "-" {
+			continue
+		}
+
+		option := v.Field(i).Interface()
+		if d, ok := option.(time.Duration); ok {
+			option = d.String()
+		}
+		options[name] = option
+	}
+
+	for k, v := range c.Extra {
+		options[k] = v
+	}
+
+	config, err := yaml.Marshal(options)
+	if err != nil {
+		return "", err
+	}
+
+	return string(config), nil
+}
 
 type setInitContainersOptions struct {
 	ClefEnabled         bool
@@ -404,6 +394,42 @@ func setBeeNodePort(o setBeeNodePortOptions) (ports service.Ports) {
 	}}
 }
 
+type setIngressTLSOptions struct {
+	Name          string
+	Host          string
+	ClusterIssuer string
+	Secret        string
+}
+
+// setIngressTLS enables TLS if secret or cert-manager's cluster issuer is set,
+// if only issuer is set, certificate is stored in the <ingress>-tls secret
+func setIngressTLS(o setIngressTLSOptions) (tls ingress.TLSs) {
+	if len(o.Secret) == 0 && len(o.ClusterIssuer) == 0 {
+		return
+	}
+
+	secret := o.Secret
+	if len(secret) == 0 {
+		secret = fmt.Sprintf("%s-tls", o.Name)
+	}
+
+	return ingress.TLSs{{
+		Hosts:      []string{o.Host},
+		SecretName: secret,
+	}}
+}
+
+// setIngressTLSAnnotations sets cert-manager's annotations if cluster issuer is set
+func setIngressTLSAnnotations(clusterIssuer string) map[string]string {
+	if len(clusterIssuer) == 0 {
+		return nil
+	}
+
+	return map[string]string{
+		"cert-manager.io/cluster-issuer": clusterIssuer,
+	}
+}
+
 func mergeMaps(a, b map[string]string) map[string]string {
 	m := map[string]string{}
 	for k, v := range a {
@@ -0,0 +1,132 @@
+package bee
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethersphere/beekeeper/pkg/k8s"
+	"github.com/ethersphere/beekeeper/pkg/k8s/ingress"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSetBeeConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config k8s.Config
+		want   map[string]interface{}
+	}{
+		{
+			name: "typed options",
+			config: k8s.Config{
+				APIAddr:    ":1633",
+				FullNode:   true,
+				NetworkID:  1987,
+				WarmupTime: 30 * time.Second,
+			},
+			want: map[string]interface{}{
+				"api-addr":    ":1633",
+				"full-node":   true,
+				"network-id":  1987,
+				"warmup-time": "30s",
+				"swap-enable": false,
+			},
+		},
+		{
+			name: "extra options",
+			config: k8s.Config{
+				APIAddr: ":1633",
+				Extra: map[string]interface{}{
+					"api-addr":   ":8080",
+					"new-option": "value",
+				},
+			},
+			want: map[string]interface{}{
+				"api-addr":   ":8080",
+				"new-option": "value",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := setBeeConfig(tc.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got map[string]interface{}
+			if err := yaml.Unmarshal([]byte(config), &got); err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range tc.want {
+				if got[k] != v {
+					t.Errorf("option %s: got %v, want %v", k, got[k], v)
+				}
+			}
+			if _, ok := got["-"]; ok {
+				t.Error("extra options rendered as an option")
+			}
+		})
+	}
+}
+
+func TestSetIngressTLS(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		o    setIngressTLSOptions
+		want ingress.TLSs
+	}{
+		{
+			name: "disabled",
+			o:    setIngressTLSOptions{Name: "bee-0-api", Host: "bee-0.localhost"},
+		},
+		{
+			name: "cluster issuer",
+			o:    setIngressTLSOptions{Name: "bee-0-api", Host: "bee-0.localhost", ClusterIssuer: "letsencrypt"},
+			want: ingress.TLSs{{Hosts: []string{"bee-0.localhost"}, SecretName: "bee-0-api-tls"}},
+		},
+		{
+			name: "secret",
+			o:    setIngressTLSOptions{Name: "bee-0-api", Host: "bee-0.localhost", Secret: "wildcard-tls"},
+			want: ingress.TLSs{{Hosts: []string{"bee-0.localhost"}, SecretName: "wildcard-tls"}},
+		},
+		{
+			name: "secret and cluster issuer",
+			o:    setIngressTLSOptions{Name: "bee-0-api", Host: "bee-0.localhost", ClusterIssuer: "letsencrypt", Secret: "wildcard-tls"},
+			want: ingress.TLSs{{Hosts: []string{"bee-0.localhost"}, SecretName: "wildcard-tls"}},
+		},
+		{
+			name: "debug ingress",
+			o:    setIngressTLSOptions{Name: "bee-0-debug", Host: "bee-0-debug.localhost", ClusterIssuer: "letsencrypt"},
+			want: ingress.TLSs{{Hosts: []string{"bee-0-debug.localhost"}, SecretName: "bee-0-debug-tls"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := setIngressTLS(tc.o); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetIngressTLSAnnotations(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		clusterIssuer string
+		want          map[string]string
+	}{
+		{
+			name: "no issuer",
+		},
+		{
+			name:          "issuer",
+			clusterIssuer: "letsencrypt",
+			want:          map[string]string{"cert-manager.io/cluster-issuer": "letsencrypt"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := setIngressTLSAnnotations(tc.clusterIssuer); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
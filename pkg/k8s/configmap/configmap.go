@@ -12,11 +12,11 @@ import (
 
 // Client manages communication with the Kubernetes ConfigMap.
 type Client struct {
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
 // NewClient constructs a new Client.
-func NewClient(clientset *kubernetes.Clientset) *Client {
+func NewClient(clientset kubernetes.Interface) *Client {
 	return &Client{
 		clientset: clientset,
 	}
@@ -72,3 +72,13 @@ func (c *Client) Delete(ctx context.Context, name, namespace string) (err error)
 
 	return
 }
+
+// Get returns ConfigMap
+func (c *Client) Get(ctx context.Context, name, namespace string) (cm *v1.ConfigMap, err error) {
+	cm, err = c.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("getting configmap %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return
+}
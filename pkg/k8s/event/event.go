@@ -0,0 +1,32 @@
+package event
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Client manages communication with the Kubernetes Events.
+type Client struct {
+	clientset kubernetes.Interface
+}
+
+// NewClient constructs a new Client.
+func NewClient(clientset kubernetes.Interface) *Client {
+	return &Client{
+		clientset: clientset,
+	}
+}
+
+// List returns all Events in the namespace
+func (c *Client) List(ctx context.Context, namespace string) (events []v1.Event, err error) {
+	l, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list events in namespace %s: %w", namespace, err)
+	}
+
+	return l.Items, nil
+}
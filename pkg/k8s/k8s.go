@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/ethersphere/beekeeper/pkg/k8s/configmap"
+	"github.com/ethersphere/beekeeper/pkg/k8s/event"
 	"github.com/ethersphere/beekeeper/pkg/k8s/ingress"
 	"github.com/ethersphere/beekeeper/pkg/k8s/namespace"
 	"github.com/ethersphere/beekeeper/pkg/k8s/persistentvolumeclaim"
@@ -15,6 +16,8 @@ import (
 	"github.com/ethersphere/beekeeper/pkg/k8s/service"
 	"github.com/ethersphere/beekeeper/pkg/k8s/serviceaccount"
 	"github.com/ethersphere/beekeeper/pkg/k8s/statefulset"
+	"github.com/ethersphere/beekeeper/pkg/k8s/volumesnapshot"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -25,10 +28,11 @@ var ErrKubeconfigNotSet = errors.New("kubeconfig is not set")
 
 // Client manages communication with the Kubernetes
 type Client struct {
-	clientset *kubernetes.Clientset // Kubernetes client must handle authentication implicitly.
+	clientset kubernetes.Interface // Kubernetes client must handle authentication implicitly.
 
 	// Services that K8S provides
 	ConfigMap      *configmap.Client
+	Event          *event.Client
 	Ingress        *ingress.Client
 	Namespace      *namespace.Client
 	Pods           *pod.Client
@@ -37,6 +41,7 @@ type Client struct {
 	ServiceAccount *serviceaccount.Client
 	Service        *service.Client
 	StatefulSet    *statefulset.Client
+	VolumeSnapshot *volumesnapshot.Client
 }
 
 // ClientOptions holds optional parameters for the Client.
@@ -67,7 +72,12 @@ func NewClient(o *ClientOptions) (c *Client, err error) {
 			return nil, fmt.Errorf("creating Kubernetes in-cluster clientset: %w", err)
 		}
 
-		return newClient(clientset), nil
+		dynamicClient, err := dynamic.NewForConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("creating Kubernetes in-cluster dynamic client: %w", err)
+		}
+
+		return newClient(clientset, dynamicClient), nil
 	}
 
 	// set client
@@ -97,15 +107,22 @@ func NewClient(o *ClientOptions) (c *Client, err error) {
 		return nil, fmt.Errorf("creating Kubernetes clientset: %w", err)
 	}
 
-	return newClient(clientset), nil
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("creating Kubernetes dynamic client: %w", err)
+	}
+
+	return newClient(clientset, dynamicClient), nil
 }
 
 // newClient constructs a new *Client with the provided http Client, which
 // should handle authentication implicitly, and sets all other services.
-func newClient(clientset *kubernetes.Clientset) (c *Client) {
+// Dynamic client is used for resources that are not part of the core API.
+func newClient(clientset kubernetes.Interface, dynamicClient dynamic.Interface) (c *Client) {
 	c = &Client{clientset: clientset}
 
 	c.ConfigMap = configmap.NewClient(clientset)
+	c.Event = event.NewClient(clientset)
 	c.Ingress = ingress.NewClient(clientset)
 	c.Namespace = namespace.NewClient(clientset)
 	c.Pods = pod.NewClient(clientset)
@@ -114,6 +131,7 @@ func newClient(clientset *kubernetes.Clientset) (c *Client) {
 	c.ServiceAccount = serviceaccount.NewClient(clientset)
 	c.Service = service.NewClient(clientset)
 	c.StatefulSet = statefulset.NewClient(clientset)
+	c.VolumeSnapshot = volumesnapshot.NewClient(dynamicClient)
 
 	return c
 }
@@ -24,11 +24,26 @@ func (c *BeeClient) Ready(ctx context.Context, name string, namespace string) (r
 	return false, k8s.ErrNotSet
 }
 
+// Restore restores Bee node's data volume, keys and configuration from the snapshot
+func (c *BeeClient) Restore(ctx context.Context, name string, namespace string, o k8s.RestoreOptions) (err error) {
+	return k8s.ErrNotSet
+}
+
 // RunningNodes returns list of running nodes
 func (c *BeeClient) RunningNodes(ctx context.Context, namespace string) (running []string, err error) {
 	return nil, k8s.ErrNotSet
 }
 
+// Snapshot creates snapshot of Bee node's data volume, keys and configuration
+func (c *BeeClient) Snapshot(ctx context.Context, name string, namespace string, o k8s.SnapshotOptions) (err error) {
+	return k8s.ErrNotSet
+}
+
+// SnapshotReady gets Bee node's snapshot readiness
+func (c *BeeClient) SnapshotReady(ctx context.Context, name string, namespace string, snapshot string) (ready bool, err error) {
+	return false, k8s.ErrNotSet
+}
+
 // Start starts Bee node in the cluster
 func (c *BeeClient) Start(ctx context.Context, name string, namespace string) (err error) {
 	return k8s.ErrNotSet
@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrBeeVersionNotKnown is returned when options can't be validated as Bee
+// version is not set or its options are not known, options that no known Bee
+// version supports are reported then, they are either new or misspelled
+var ErrBeeVersionNotKnown = errors.New("options for Bee version not known")
+
+// beeOptions lists options supported by Bee, keyed by Bee's major.minor version
+var beeOptions = map[string][]string{
+	"0.5": {
+		"api-addr", "bootnode", "bootnode-mode", "clef-signer-enable", "clef-signer-endpoint", "clef-signer-ethereum-address",
+		"cors-allowed-origins", "data-dir", "db-block-cache-capacity", "db-capacity", "db-disable-seeks-compaction",
+		"db-open-files-limit", "db-write-buffer-size", "debug-api-addr", "debug-api-enable", "gateway-mode",
+		"global-pinning-enable", "nat-addr", "network-id", "p2p-addr", "p2p-quic-enable", "p2p-ws-enable", "password",
+		"password-file", "payment-early", "payment-threshold", "payment-tolerance", "resolver-options", "standalone",
+		"swap-enable", "swap-endpoint", "swap-factory-address", "swap-initial-deposit", "tracing-enable",
+		"tracing-endpoint", "tracing-service-name", "verbosity", "welcome-message",
+	},
+	"0.6": {
+		"api-addr", "block-hash", "block-time", "bootnode", "bootnode-mode", "cache-capacity", "clef-signer-enable",
+		"clef-signer-endpoint", "clef-signer-ethereum-address", "cors-allowed-origins", "data-dir",
+		"db-block-cache-capacity", "db-disable-seeks-compaction", "db-open-files-limit", "db-write-buffer-size",
+		"debug-api-addr", "debug-api-enable", "full-node", "gateway-mode", "global-pinning-enable", "nat-addr",
+		"network-id", "p2p-addr", "p2p-quic-enable", "p2p-ws-enable", "password", "password-file", "payment-early",
+		"payment-threshold", "payment-tolerance", "postage-stamp-address", "price-oracle-address", "resolver-options",
+		"standalone", "swap-deployment-gas-price", "swap-enable", "swap-endpoint", "swap-factory-address",
+		"swap-initial-deposit", "swap-legacy-factory-addresses", "tracing-enable", "tracing-endpoint",
+		"tracing-service-name", "transaction", "verbosity", "warmup-time", "welcome-message",
+	},
+}
+
+// ValidateBeeOptions checks whether all options are supported by the given Bee
+// version. If the version is not set or not known, ErrBeeVersionNotKnown is
+// returned for options that no known version supports, so that new options
+// can be tested without a Beekeeper release.
+func ValidateBeeOptions(version string, options map[string]interface{}) (err error) {
+	// use major.minor version, e.g. 0.6 for v0.6.2-rc1
+	v := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(v) >= 2 {
+		if supported, ok := beeOptions[v[0]+"."+v[1]]; ok {
+			if unknown := unsupportedOptions(supported, options); len(unknown) > 0 {
+				return fmt.Errorf("options not supported by Bee version %s: %s", version, strings.Join(unknown, ", "))
+			}
+			return nil
+		}
+	}
+
+	var known []string
+	for _, supported := range beeOptions {
+		known = append(known, supported...)
+	}
+	if unknown := unsupportedOptions(known, options); len(unknown) > 0 {
+		if len(version) == 0 {
+			version = "not set"
+		}
+		return fmt.Errorf("%w, version %s, options not supported by any known version: %s", ErrBeeVersionNotKnown, version, strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// unsupportedOptions returns sorted options that are not supported
+func unsupportedOptions(supported []string, options map[string]interface{}) (unknown []string) {
+	for o := range options {
+		if !contains(supported, o) {
+			unknown = append(unknown, o)
+		}
+	}
+	sort.Strings(unknown)
+	return
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
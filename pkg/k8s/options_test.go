@@ -0,0 +1,82 @@
+package k8s
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateBeeOptions(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		version  string
+		options  map[string]interface{}
+		err      bool
+		notKnown bool
+	}{
+		{
+			name:    "supported",
+			version: "0.6",
+			options: map[string]interface{}{"block-hash": "", "full-node": true},
+		},
+		{
+			name:    "supported with patch and prefix",
+			version: "v0.6.2-rc1",
+			options: map[string]interface{}{"block-hash": ""},
+		},
+		{
+			name:    "not supported by version",
+			version: "0.5",
+			options: map[string]interface{}{"block-hash": ""},
+			err:     true,
+		},
+		{
+			name:    "unknown option of known version",
+			version: "0.6",
+			options: map[string]interface{}{"new-option": 1},
+			err:     true,
+		},
+		{
+			name:    "known option of unknown version",
+			version: "1.0.0",
+			options: map[string]interface{}{"full-node": true},
+		},
+		{
+			name:     "unknown option of unknown version",
+			version:  "1.0.0",
+			options:  map[string]interface{}{"new-option": 1},
+			err:      true,
+			notKnown: true,
+		},
+		{
+			name:    "known option without version",
+			options: map[string]interface{}{"full-node": true},
+		},
+		{
+			name:     "unknown option without version",
+			options:  map[string]interface{}{"new-option": 1},
+			err:      true,
+			notKnown: true,
+		},
+		{
+			name:     "invalid version",
+			version:  "latest",
+			options:  map[string]interface{}{"new-option": 1},
+			err:      true,
+			notKnown: true,
+		},
+		{
+			name:    "no options",
+			version: "0.5",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateBeeOptions(tc.version, tc.options)
+			if tc.err != (err != nil) {
+				t.Fatalf("got error %v", err)
+			}
+			if tc.notKnown != errors.Is(err, ErrBeeVersionNotKnown) {
+				t.Fatalf("got error %v, expected version not known %t", err, tc.notKnown)
+			}
+		})
+	}
+}
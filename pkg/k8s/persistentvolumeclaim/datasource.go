@@ -11,6 +11,10 @@ type DataSource struct {
 
 // toK8S converts DataSource to Kuberntes client object
 func (d *DataSource) toK8S() *v1.TypedLocalObjectReference {
+	if len(d.Kind) == 0 || len(d.Name) == 0 {
+		return nil
+	}
+
 	return &v1.TypedLocalObjectReference{
 		APIGroup: &d.APIGroup,
 		Kind:     d.Kind,
@@ -7,9 +7,9 @@ import (
 
 // Affinity represents Kubernetes Affinity
 type Affinity struct {
-	NodeAffinity    *NodeAffinity
-	PodAffinity     *PodAffinity
-	PodAntiAffinity *PodAntiAffinity
+	NodeAffinity    *NodeAffinity    `yaml:"node-affinity"`
+	PodAffinity     *PodAffinity     `yaml:"pod-affinity"`
+	PodAntiAffinity *PodAntiAffinity `yaml:"pod-anti-affinity"`
 }
 
 func (a *Affinity) toK8S() *v1.Affinity {
@@ -28,8 +28,8 @@ func (a *Affinity) toK8S() *v1.Affinity {
 
 // NodeAffinity represents Kubernetes NodeAffinity
 type NodeAffinity struct {
-	PreferredDuringSchedulingIgnoredDuringExecution PreferredSchedulingTerms
-	RequiredDuringSchedulingIgnoredDuringExecution  NodeSelector
+	PreferredDuringSchedulingIgnoredDuringExecution PreferredSchedulingTerms `yaml:"preferred-during-scheduling-ignored-during-execution"`
+	RequiredDuringSchedulingIgnoredDuringExecution  NodeSelector             `yaml:"required-during-scheduling-ignored-during-execution"`
 }
 
 // toK8S converts NodeAffinity to Kuberntes client object
@@ -55,8 +55,8 @@ func (psts PreferredSchedulingTerms) toK8S() (l []v1.PreferredSchedulingTerm) {
 
 // PreferredSchedulingTerm represents Kubernetes PreferredSchedulingTerm
 type PreferredSchedulingTerm struct {
-	Preference NodeSelectorTerm
-	Weight     int32
+	Preference NodeSelectorTerm `yaml:"preference"`
+	Weight     int32            `yaml:"weight"`
 }
 
 // toK8S converts PreferredSchedulingTerm to Kuberntes client object
@@ -69,11 +69,15 @@ func (pst *PreferredSchedulingTerm) toK8S() v1.PreferredSchedulingTerm {
 
 // NodeSelector represents Kubernetes NodeSelector
 type NodeSelector struct {
-	NodeSelectorTerms NodeSelectorTerms
+	NodeSelectorTerms NodeSelectorTerms `yaml:"node-selector-terms"`
 }
 
 // toK8S converts NodeSelector to Kuberntes client object
 func (ns *NodeSelector) toK8S() *v1.NodeSelector {
+	if len(ns.NodeSelectorTerms) == 0 {
+		return nil
+	}
+
 	return &v1.NodeSelector{
 		NodeSelectorTerms: ns.NodeSelectorTerms.toK8S(),
 	}
@@ -95,8 +99,8 @@ func (nsts NodeSelectorTerms) toK8S() (l []v1.NodeSelectorTerm) {
 
 // NodeSelectorTerm represents Kubernetes NodeSelectorTerm
 type NodeSelectorTerm struct {
-	MatchExpressions NodeSelectorRequirements
-	MatchFields      NodeSelectorRequirements
+	MatchExpressions NodeSelectorRequirements `yaml:"match-expressions"`
+	MatchFields      NodeSelectorRequirements `yaml:"match-fields"`
 }
 
 // toK8S converts NodeSelectorTerm to Kuberntes client object
@@ -123,9 +127,9 @@ func (nsrs NodeSelectorRequirements) toK8S() (l []v1.NodeSelectorRequirement) {
 
 // NodeSelectorRequirement represents Kubernetes NodeSelectorRequirement
 type NodeSelectorRequirement struct {
-	Key      string
-	Operator string
-	Values   []string
+	Key      string   `yaml:"key"`
+	Operator string   `yaml:"operator"`
+	Values   []string `yaml:"values"`
 }
 
 // toK8S converts NodeSelectorRequirement to Kuberntes client object
@@ -139,8 +143,8 @@ func (nsr *NodeSelectorRequirement) toK8S() v1.NodeSelectorRequirement {
 
 // PodAffinity represents Kubernetes PodAffinity
 type PodAffinity struct {
-	PreferredDuringSchedulingIgnoredDuringExecution WeightedPodAffinityTerms
-	RequiredDuringSchedulingIgnoredDuringExecution  PodAffinityTerms
+	PreferredDuringSchedulingIgnoredDuringExecution WeightedPodAffinityTerms `yaml:"preferred-during-scheduling-ignored-during-execution"`
+	RequiredDuringSchedulingIgnoredDuringExecution  PodAffinityTerms         `yaml:"required-during-scheduling-ignored-during-execution"`
 }
 
 // toK8S converts PodAffinity to Kuberntes client object
@@ -167,9 +171,9 @@ func (pats PodAffinityTerms) toK8S() (l []v1.PodAffinityTerm) {
 
 // PodAffinityTerm represents Kubernetes PodAffinityTerm
 type PodAffinityTerm struct {
-	LabelSelector map[string]string
-	Namespaces    []string
-	TopologyKey   string
+	LabelSelector map[string]string `yaml:"label-selector"`
+	Namespaces    []string          `yaml:"namespaces"`
+	TopologyKey   string            `yaml:"topology-key"`
 }
 
 // toK8S converts PodAffinityTerm to Kuberntes client object
@@ -197,8 +201,8 @@ func (wpats WeightedPodAffinityTerms) toK8S() (l []v1.WeightedPodAffinityTerm) {
 
 // WeightedPodAffinityTerm represents Kubernetes WeightedPodAffinityTerm
 type WeightedPodAffinityTerm struct {
-	PodAffinityTerm PodAffinityTerm
-	Weight          int32
+	PodAffinityTerm PodAffinityTerm `yaml:"pod-affinity-term"`
+	Weight          int32           `yaml:"weight"`
 }
 
 // toK8S converts WeightedPodAffinityTerm to Kuberntes client object
@@ -211,8 +215,8 @@ func (wpat *WeightedPodAffinityTerm) toK8S() v1.WeightedPodAffinityTerm {
 
 // PodAntiAffinity represents Kubernetes PodAntiAffinity
 type PodAntiAffinity struct {
-	RequiredDuringSchedulingIgnoredDuringExecution  PodAffinityTerms
-	PreferredDuringSchedulingIgnoredDuringExecution WeightedPodAffinityTerms
+	RequiredDuringSchedulingIgnoredDuringExecution  PodAffinityTerms         `yaml:"required-during-scheduling-ignored-during-execution"`
+	PreferredDuringSchedulingIgnoredDuringExecution WeightedPodAffinityTerms `yaml:"preferred-during-scheduling-ignored-during-execution"`
 }
 
 // toK8S converts PodAntiAffinity to Kuberntes client object
@@ -3,6 +3,7 @@ package pod
 import (
 	"context"
 	"fmt"
+	"io"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -12,11 +13,11 @@ import (
 
 // Client manages communication with the Kubernetes Pods.
 type Client struct {
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
 // NewClient constructs a new Client.
-func NewClient(clientset *kubernetes.Clientset) *Client {
+func NewClient(clientset kubernetes.Interface) *Client {
 	return &Client{
 		clientset: clientset,
 	}
@@ -68,3 +69,24 @@ func (c *Client) Delete(ctx context.Context, name, namespace string) (err error)
 
 	return
 }
+
+// Get returns Pod
+func (c *Client) Get(ctx context.Context, name, namespace string) (pod *v1.Pod, err error) {
+	pod, err = c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("getting pod %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return
+}
+
+// Logs returns stream of Pod's container logs, if previous is set logs of the
+// previous, terminated, instance of the container are returned
+func (c *Client) Logs(ctx context.Context, name, namespace, container string, previous bool) (logs io.ReadCloser, err error) {
+	logs, err = c.clientset.CoreV1().Pods(namespace).GetLogs(name, &v1.PodLogOptions{Container: container, Previous: previous}).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting logs of container %s in pod %s in namespace %s: %w", container, name, namespace, err)
+	}
+
+	return
+}
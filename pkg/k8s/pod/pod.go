@@ -97,7 +97,13 @@ func (p *PodSpec) toK8S() v1.PodSpec {
 			}
 			return nil
 		}(),
-		Priority:                      &p.Priority,
+		Priority: func() *int32 {
+			// priority must not be set together with priority class
+			if p.Priority != 0 {
+				return &p.Priority
+			}
+			return nil
+		}(),
 		PriorityClassName:             p.PriorityClassName,
 		ReadinessGates:                p.ReadinessGates.toK8S(),
 		RestartPolicy:                 v1.RestartPolicy(p.RestartPolicy),
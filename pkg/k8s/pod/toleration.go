@@ -18,11 +18,11 @@ func (ts Tolerations) toK8S() (l []v1.Toleration) {
 
 // Toleration represents Kubernetes Toleration
 type Toleration struct {
-	Key               string
-	Operator          string
-	Value             string
-	Effect            string
-	TolerationSeconds int64
+	Key               string `yaml:"key"`
+	Operator          string `yaml:"operator"`
+	Value             string `yaml:"value"`
+	Effect            string `yaml:"effect"`
+	TolerationSeconds *int64 `yaml:"toleration-seconds"` // only valid for NoExecute effect
 }
 
 // toK8S converts Toleration to Kuberntes client object
@@ -32,6 +32,6 @@ func (t *Toleration) toK8S() v1.Toleration {
 		Operator:          v1.TolerationOperator(t.Operator),
 		Value:             t.Value,
 		Effect:            v1.TaintEffect(t.Effect),
-		TolerationSeconds: &t.TolerationSeconds,
+		TolerationSeconds: t.TolerationSeconds,
 	}
 }
@@ -21,10 +21,10 @@ func (tscs TopologySpreadConstraints) toK8S() (l []v1.TopologySpreadConstraint)
 
 // TopologySpreadConstraint represents Kubernetes TopologySpreadConstraint
 type TopologySpreadConstraint struct {
-	MaxSkew           int32
-	TopologyKey       string
-	WhenUnsatisfiable string
-	LabelSelector     map[string]string
+	MaxSkew           int32             `yaml:"max-skew"`
+	TopologyKey       string            `yaml:"topology-key"`
+	WhenUnsatisfiable string            `yaml:"when-unsatisfiable"`
+	LabelSelector     map[string]string `yaml:"label-selector"`
 }
 
 // toK8S converts TopologySpreadConstraint to Kuberntes client object
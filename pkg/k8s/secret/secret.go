@@ -12,11 +12,11 @@ import (
 
 // Client manages communication with the Kubernetes Secret.
 type Client struct {
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
 // NewClient constructs a new Client.
-func NewClient(clientset *kubernetes.Clientset) *Client {
+func NewClient(clientset kubernetes.Interface) *Client {
 	return &Client{
 		clientset: clientset,
 	}
@@ -74,3 +74,13 @@ func (c *Client) Delete(ctx context.Context, name, namespace string) (err error)
 
 	return
 }
+
+// Get returns Secret
+func (c *Client) Get(ctx context.Context, name, namespace string) (s *v1.Secret, err error) {
+	s, err = c.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("getting secret %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return
+}
@@ -13,11 +13,11 @@ import (
 
 // Client manages communication with the Kubernetes StatefulSet.
 type Client struct {
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
 // NewClient constructs a new Client.
-func NewClient(clientset *kubernetes.Clientset) *Client {
+func NewClient(clientset kubernetes.Interface) *Client {
 	return &Client{
 		clientset: clientset,
 	}
@@ -43,6 +43,16 @@ func (c *Client) Delete(ctx context.Context, name, namespace string) (err error)
 	return
 }
 
+// Get returns StatefulSet
+func (c *Client) Get(ctx context.Context, name, namespace string) (s *appsv1.StatefulSet, err error) {
+	s, err = c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("getting statefulset %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return
+}
+
 // ReadyReplicas returns number of Pods created by the StatefulSet controller that have a Ready Condition
 func (c *Client) ReadyReplicas(ctx context.Context, name, namespace string) (ready int32, err error) {
 	s, err := c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
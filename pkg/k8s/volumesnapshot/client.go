@@ -0,0 +1,102 @@
+package volumesnapshot
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// APIGroup is API group of the VolumeSnapshot resource
+const APIGroup = "snapshot.storage.k8s.io"
+
+var resource = schema.GroupVersionResource{
+	Group:    APIGroup,
+	Version:  "v1",
+	Resource: "volumesnapshots",
+}
+
+// Client manages communication with the Kubernetes VolumeSnapshots.
+type Client struct {
+	dynamic dynamic.Interface
+}
+
+// NewClient constructs a new Client.
+func NewClient(dynamic dynamic.Interface) *Client {
+	return &Client{
+		dynamic: dynamic,
+	}
+}
+
+// Options holds optional parameters for the Client.
+type Options struct {
+	Annotations           map[string]string
+	Labels                map[string]string
+	PersistentVolumeClaim string
+	VolumeSnapshotClass   string
+}
+
+// Create creates VolumeSnapshot of the PersistentVolumeClaim
+func (c *Client) Create(ctx context.Context, name, namespace string, o Options) (err error) {
+	source := map[string]interface{}{
+		"persistentVolumeClaimName": o.PersistentVolumeClaim,
+	}
+	spec := map[string]interface{}{
+		"source": source,
+	}
+	if len(o.VolumeSnapshotClass) > 0 {
+		spec["volumeSnapshotClassName"] = o.VolumeSnapshotClass
+	}
+
+	s := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": resource.GroupVersion().String(),
+		"kind":       "VolumeSnapshot",
+		"spec":       spec,
+	}}
+	s.SetName(name)
+	s.SetNamespace(namespace)
+	s.SetAnnotations(o.Annotations)
+	s.SetLabels(o.Labels)
+
+	if _, err = c.dynamic.Resource(resource).Namespace(namespace).Create(ctx, s, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("creating volumesnapshot %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return
+}
+
+// Delete deletes VolumeSnapshot
+func (c *Client) Delete(ctx context.Context, name, namespace string) (err error) {
+	err = c.dynamic.Resource(resource).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("deleting volumesnapshot %s in namespace %s: %w", name, namespace, err)
+	}
+
+	return
+}
+
+// ReadyToUse returns whether VolumeSnapshot is ready to be used as a data source
+func (c *Client) ReadyToUse(ctx context.Context, name, namespace string) (ready bool, err error) {
+	s, err := c.dynamic.Resource(resource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("getting volumesnapshot %s in namespace %s: %w", name, namespace, err)
+	}
+
+	if msg, ok, _ := unstructured.NestedString(s.Object, "status", "error", "message"); ok {
+		return false, fmt.Errorf("volumesnapshot %s in namespace %s: %s", name, namespace, msg)
+	}
+
+	ready, _, err = unstructured.NestedBool(s.Object, "status", "readyToUse")
+	if err != nil {
+		return false, fmt.Errorf("volumesnapshot %s in namespace %s status: %w", name, namespace, err)
+	}
+
+	return
+}
@@ -0,0 +1,141 @@
+package volumesnapshot
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestCreate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		o    Options
+		want map[string]interface{}
+	}{
+		{
+			name: "default class",
+			o:    Options{PersistentVolumeClaim: "data-bee-0-0"},
+			want: map[string]interface{}{
+				"source": map[string]interface{}{"persistentVolumeClaimName": "data-bee-0-0"},
+			},
+		},
+		{
+			name: "class",
+			o:    Options{PersistentVolumeClaim: "data-bee-0-0", VolumeSnapshotClass: "csi-snapclass"},
+			want: map[string]interface{}{
+				"source":                  map[string]interface{}{"persistentVolumeClaimName": "data-bee-0-0"},
+				"volumeSnapshotClassName": "csi-snapclass",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			d := newFakeDynamicClient()
+			c := NewClient(d)
+
+			tc.o.Labels = map[string]string{"app": "bee"}
+			if err := c.Create(ctx, "snap-bee-0", "test", tc.o); err != nil {
+				t.Fatal(err)
+			}
+
+			s, err := d.Resource(resource).Namespace("test").Get(ctx, "snap-bee-0", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.GetKind() != "VolumeSnapshot" || s.GetAPIVersion() != "snapshot.storage.k8s.io/v1" {
+				t.Fatalf("got kind %s and api version %s", s.GetKind(), s.GetAPIVersion())
+			}
+			if !reflect.DeepEqual(s.GetLabels(), tc.o.Labels) {
+				t.Fatalf("got labels %v, want %v", s.GetLabels(), tc.o.Labels)
+			}
+			spec, _, err := unstructured.NestedMap(s.Object, "spec")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(spec, tc.want) {
+				t.Fatalf("got spec %v, want %v", spec, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadyToUse(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		status  map[string]interface{}
+		want    bool
+		wantErr bool
+	}{
+		{name: "no status"},
+		{name: "not ready", status: map[string]interface{}{"readyToUse": false}},
+		{name: "ready", status: map[string]interface{}{"readyToUse": true}, want: true},
+		{name: "error", status: map[string]interface{}{"readyToUse": false, "error": map[string]interface{}{"message": "failed to take snapshot"}}, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			d := newFakeDynamicClient()
+			c := NewClient(d)
+
+			if err := c.Create(ctx, "snap-bee-0", "test", Options{PersistentVolumeClaim: "data-bee-0-0"}); err != nil {
+				t.Fatal(err)
+			}
+			if tc.status != nil {
+				s, err := d.Resource(resource).Namespace("test").Get(ctx, "snap-bee-0", metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				s.Object["status"] = tc.status
+				if _, err := d.Resource(resource).Namespace("test").Update(ctx, s, metav1.UpdateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			ready, err := c.ReadyToUse(ctx, "snap-bee-0", "test")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ready != tc.want {
+				t.Fatalf("got ready %t, want %t", ready, tc.want)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	d := newFakeDynamicClient()
+	c := NewClient(d)
+
+	if err := c.Create(ctx, "snap-bee-0", "test", Options{PersistentVolumeClaim: "data-bee-0-0"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete(ctx, "snap-bee-0", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ReadyToUse(ctx, "snap-bee-0", "test"); err == nil {
+		t.Fatal("volumesnapshot not deleted")
+	}
+
+	// deleting missing volumesnapshot is not an error
+	if err := c.Delete(ctx, "snap-bee-0", "test"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newFakeDynamicClient() *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		resource: "VolumeSnapshotList",
+	})
+}
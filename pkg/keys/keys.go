@@ -0,0 +1,154 @@
+package keys
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	beecrypto "github.com/ethersphere/bee/pkg/crypto"
+	"github.com/ethersphere/bee/pkg/swarm"
+)
+
+const (
+	// scrypt parameters used by Bee's file keystore
+	scryptN = 1 << 15
+	scryptP = 1
+
+	keyVersion = 3
+)
+
+// Options represents keys generation options
+type Options struct {
+	Seed         string // cluster seed keys are derived from
+	Password     string // password for encrypting libp2p and swarm keys
+	ClefEnabled  bool   // generate clef key
+	ClefPassword string // password for encrypting clef key, derived from the seed if not set
+	NetworkID    uint64 // network ID used for calculating overlay address
+}
+
+// Keys represents node's keys in the encrypted JSON v3 key file format
+type Keys struct {
+	ClefKey      string
+	ClefPassword string
+	LibP2PKey    string
+	SwarmKey     string
+	// addresses of the keys
+	Ethereum string
+	Overlay  swarm.Address
+}
+
+// Generate derives node's keys deterministically from the seed and node name.
+// Keys are encrypted with random salt, so generated key files differ between
+// calls, but keys in them are always the same.
+func Generate(node string, o Options) (k Keys, err error) {
+	if len(o.Seed) == 0 {
+		return Keys{}, fmt.Errorf("seed not set")
+	}
+
+	libp2p, err := derivePrivateKey(o.Seed, node, "libp2p")
+	if err != nil {
+		return Keys{}, fmt.Errorf("derive libp2p key: %w", err)
+	}
+	if k.LibP2PKey, err = encryptKey(libp2p, o.Password, o.Seed, node, "libp2p"); err != nil {
+		return Keys{}, fmt.Errorf("encrypt libp2p key: %w", err)
+	}
+
+	swarmKey, err := derivePrivateKey(o.Seed, node, "swarm")
+	if err != nil {
+		return Keys{}, fmt.Errorf("derive swarm key: %w", err)
+	}
+	if k.SwarmKey, err = encryptKey(swarmKey, o.Password, o.Seed, node, "swarm"); err != nil {
+		return Keys{}, fmt.Errorf("encrypt swarm key: %w", err)
+	}
+
+	// node's ethereum address is the one of the clef key when clef is enabled
+	ethereum := swarmKey
+	if o.ClefEnabled {
+		clef, err := derivePrivateKey(o.Seed, node, "clef")
+		if err != nil {
+			return Keys{}, fmt.Errorf("derive clef key: %w", err)
+		}
+
+		k.ClefPassword = o.ClefPassword
+		if len(k.ClefPassword) == 0 {
+			k.ClefPassword = hex.EncodeToString(crypto.Keccak256([]byte(o.Seed), []byte(node), []byte("clef-password"))[:16])
+		}
+		if k.ClefKey, err = encryptKey(clef, k.ClefPassword, o.Seed, node, "clef"); err != nil {
+			return Keys{}, fmt.Errorf("encrypt clef key: %w", err)
+		}
+		ethereum = clef
+	}
+
+	k.Ethereum = crypto.PubkeyToAddress(ethereum.PublicKey).Hex()
+	if k.Overlay, err = beecrypto.NewOverlayAddress(ethereum.PublicKey, o.NetworkID); err != nil {
+		return Keys{}, fmt.Errorf("overlay address: %w", err)
+	}
+
+	return
+}
+
+// derivePrivateKey derives secp256k1 private key from the seed, node name and key purpose
+func derivePrivateKey(seed, node, purpose string) (*ecdsa.PrivateKey, error) {
+	h := crypto.Keccak256([]byte(seed), []byte(node), []byte(purpose))
+	for i := 0; i < 16; i++ {
+		if k, err := crypto.ToECDSA(h); err == nil {
+			return k, nil
+		}
+		// hash is out of secp256k1 curve order, rehash it
+		h = crypto.Keccak256(h)
+	}
+
+	return nil, fmt.Errorf("no valid key for node %s", node)
+}
+
+// encryptedKey represents key in the Ethereum JSON v3 key file format that is used by Bee and Clef
+type encryptedKey struct {
+	Address string              `json:"address"`
+	Crypto  keystore.CryptoJSON `json:"crypto"`
+	ID      string              `json:"id"`
+	Version int                 `json:"version"`
+}
+
+// encryptKey encrypts private key with password, key ID is derived from the seed
+func encryptKey(k *ecdsa.PrivateKey, password, seed, node, purpose string) (string, error) {
+	c, err := keystore.EncryptDataV3(crypto.FromECDSA(k), []byte(password), scryptN, scryptP)
+	if err != nil {
+		return "", err
+	}
+
+	// UUID v4 format
+	id := crypto.Keccak256([]byte(seed), []byte(node), []byte(purpose), []byte("id"))[:16]
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+
+	data, err := json.Marshal(encryptedKey{
+		Address: hex.EncodeToString(crypto.PubkeyToAddress(k.PublicKey).Bytes()),
+		Crypto:  c,
+		ID:      fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:]),
+		Version: keyVersion,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// Decrypt decrypts key in the Ethereum JSON v3 key file format and returns
+// hex encoded private key
+func Decrypt(key, password string) (privateKey string, err error) {
+	var k encryptedKey
+	if err := json.Unmarshal([]byte(key), &k); err != nil {
+		return "", fmt.Errorf("unmarshal key: %w", err)
+	}
+
+	data, err := keystore.DecryptDataV3(k.Crypto, password)
+	if err != nil {
+		return "", fmt.Errorf("decrypt key: %w", err)
+	}
+
+	return hex.EncodeToString(data), nil
+}
@@ -0,0 +1,110 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		o    Options
+	}{
+		{
+			name: "without clef",
+			o:    Options{Seed: "cluster-seed", Password: "password", NetworkID: 1},
+		},
+		{
+			name: "with clef",
+			o:    Options{Seed: "cluster-seed", Password: "password", ClefEnabled: true, NetworkID: 1},
+		},
+		{
+			name: "with clef password",
+			o:    Options{Seed: "cluster-seed", Password: "password", ClefEnabled: true, ClefPassword: "clef", NetworkID: 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			k1, err := Generate("bee-0", tc.o)
+			if err != nil {
+				t.Fatal(err)
+			}
+			k2, err := Generate("bee-0", tc.o)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if k1.Ethereum != k2.Ethereum {
+				t.Fatalf("got ethereum addresses %s and %s for the same seed", k1.Ethereum, k2.Ethereum)
+			}
+			if !k1.Overlay.Equal(k2.Overlay) {
+				t.Fatalf("got overlays %s and %s for the same seed", k1.Overlay, k2.Overlay)
+			}
+
+			// keys are encrypted with random salt, but decrypt to the same keys
+			if k1.SwarmKey == k2.SwarmKey {
+				t.Fatal("got the same encrypted swarm key twice")
+			}
+			for _, keys := range [][2]string{{k1.LibP2PKey, k2.LibP2PKey}, {k1.SwarmKey, k2.SwarmKey}} {
+				if d1, d2 := decrypt(t, keys[0], tc.o.Password), decrypt(t, keys[1], tc.o.Password); d1 != d2 {
+					t.Fatalf("got keys %s and %s for the same seed", d1, d2)
+				}
+			}
+
+			// ethereum address is the one of the clef key if clef is enabled
+			ethereumKey, password := k1.SwarmKey, tc.o.Password
+			if tc.o.ClefEnabled {
+				if len(tc.o.ClefPassword) > 0 && k1.ClefPassword != tc.o.ClefPassword {
+					t.Fatalf("got clef password %s, want %s", k1.ClefPassword, tc.o.ClefPassword)
+				}
+				if k1.ClefPassword != k2.ClefPassword {
+					t.Fatalf("got clef passwords %s and %s for the same seed", k1.ClefPassword, k2.ClefPassword)
+				}
+				ethereumKey, password = k1.ClefKey, k1.ClefPassword
+			} else if len(k1.ClefKey) > 0 {
+				t.Fatal("got clef key with clef disabled")
+			}
+			pk, err := crypto.HexToECDSA(decrypt(t, ethereumKey, password))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := crypto.PubkeyToAddress(pk.PublicKey).Hex(); got != k1.Ethereum {
+				t.Fatalf("got ethereum address %s of the decrypted key, want %s", got, k1.Ethereum)
+			}
+
+			other, err := Generate("bee-1", tc.o)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if other.Ethereum == k1.Ethereum || other.Overlay.Equal(k1.Overlay) {
+				t.Fatal("got the same addresses for different nodes")
+			}
+		})
+	}
+}
+
+func TestGenerateWithoutSeed(t *testing.T) {
+	if _, err := Generate("bee-0", Options{Password: "password"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDecryptWrongPassword(t *testing.T) {
+	k, err := Generate("bee-0", Options{Seed: "cluster-seed", Password: "password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decrypt(k.SwarmKey, "wrong"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func decrypt(t *testing.T, key, password string) string {
+	t.Helper()
+
+	d, err := Decrypt(key, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
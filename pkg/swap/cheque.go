@@ -0,0 +1,59 @@
+package swap
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethersphere/sw3-bindings/v3/erc20simpleswap"
+)
+
+// ChequeCashed represents ChequeCashed event emitted by the chequebook contract
+type ChequeCashed struct {
+	Chequebook       string
+	Beneficiary      string
+	Recipient        string
+	Caller           string
+	TotalPayout      *big.Int
+	CumulativePayout *big.Int
+	CallerPayout     *big.Int
+}
+
+// ChequeCashedEvents decodes ChequeCashed events from the receipt's logs
+func ChequeCashedEvents(r *types.Receipt) (events []ChequeCashed, err error) {
+	chequebookABI, err := abi.JSON(strings.NewReader(erc20simpleswap.ERC20SimpleSwapABI))
+	if err != nil {
+		return nil, fmt.Errorf("parse chequebook ABI: %w", err)
+	}
+	event := chequebookABI.Events["ChequeCashed"]
+
+	for _, l := range r.Logs {
+		if len(l.Topics) != 4 || l.Topics[0] != event.ID {
+			continue
+		}
+
+		var data struct {
+			TotalPayout      *big.Int
+			CumulativePayout *big.Int
+			CallerPayout     *big.Int
+		}
+		if err := chequebookABI.UnpackIntoInterface(&data, "ChequeCashed", l.Data); err != nil {
+			return nil, fmt.Errorf("unpack ChequeCashed event: %w", err)
+		}
+
+		events = append(events, ChequeCashed{
+			Chequebook:       l.Address.Hex(),
+			Beneficiary:      common.BytesToAddress(l.Topics[1].Bytes()).Hex(),
+			Recipient:        common.BytesToAddress(l.Topics[2].Bytes()).Hex(),
+			Caller:           common.BytesToAddress(l.Topics[3].Bytes()).Hex(),
+			TotalPayout:      data.TotalPayout,
+			CumulativePayout: data.CumulativePayout,
+			CallerPayout:     data.CallerPayout,
+		})
+	}
+
+	return
+}
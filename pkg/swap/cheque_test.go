@@ -0,0 +1,114 @@
+package swap
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethersphere/sw3-bindings/v3/erc20simpleswap"
+)
+
+func TestChequeCashedEvents(t *testing.T) {
+	chequebookABI, err := abi.JSON(strings.NewReader(erc20simpleswap.ERC20SimpleSwapABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cashed := chequebookABI.Events["ChequeCashed"]
+	withdraw := chequebookABI.Events["Withdraw"]
+
+	data, err := cashed.Inputs.NonIndexed().Pack(big.NewInt(100), big.NewInt(300), big.NewInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	withdrawData, err := withdraw.Inputs.NonIndexed().Pack(big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chequebook := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	beneficiary := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	recipient := common.HexToAddress("0x3000000000000000000000000000000000000003")
+	caller := common.HexToAddress("0x4000000000000000000000000000000000000004")
+	topics := []common.Hash{cashed.ID, beneficiary.Hash(), recipient.Hash(), caller.Hash()}
+
+	valid := ChequeCashed{
+		Chequebook:       chequebook.Hex(),
+		Beneficiary:      beneficiary.Hex(),
+		Recipient:        recipient.Hex(),
+		Caller:           caller.Hex(),
+		TotalPayout:      big.NewInt(100),
+		CumulativePayout: big.NewInt(300),
+		CallerPayout:     big.NewInt(5),
+	}
+
+	for _, tc := range []struct {
+		name    string
+		logs    []*types.Log
+		want    []ChequeCashed
+		wantErr bool
+	}{
+		{
+			name: "no logs",
+		},
+		{
+			name: "valid event",
+			logs: []*types.Log{{Address: chequebook, Topics: topics, Data: data}},
+			want: []ChequeCashed{valid},
+		},
+		{
+			name: "wrong topic count",
+			logs: []*types.Log{{Address: chequebook, Topics: topics[:3], Data: data}},
+		},
+		{
+			name: "foreign event",
+			logs: []*types.Log{{Address: chequebook, Topics: []common.Hash{withdraw.ID}, Data: withdrawData}},
+		},
+		{
+			name: "foreign event id with cheque cashed topics",
+			logs: []*types.Log{{Address: chequebook, Topics: []common.Hash{withdraw.ID, topics[1], topics[2], topics[3]}, Data: data}},
+		},
+		{
+			name: "valid event among others",
+			logs: []*types.Log{
+				{Address: chequebook, Topics: []common.Hash{withdraw.ID}, Data: withdrawData},
+				{Address: chequebook, Topics: topics, Data: data},
+				{Address: chequebook, Topics: topics[:1], Data: data},
+			},
+			want: []ChequeCashed{valid},
+		},
+		{
+			name:    "truncated data",
+			logs:    []*types.Log{{Address: chequebook, Topics: topics, Data: data[:64]}},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ChequeCashedEvents(&types.Receipt{Logs: tc.logs})
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("got %d events, want %d", len(got), len(tc.want))
+			}
+			for i, e := range got {
+				w := tc.want[i]
+				if e.Chequebook != w.Chequebook || e.Beneficiary != w.Beneficiary || e.Recipient != w.Recipient || e.Caller != w.Caller {
+					t.Fatalf("got addresses %s, %s, %s and %s, want %s, %s, %s and %s", e.Chequebook, e.Beneficiary, e.Recipient, e.Caller, w.Chequebook, w.Beneficiary, w.Recipient, w.Caller)
+				}
+				if e.TotalPayout.Cmp(w.TotalPayout) != 0 || e.CumulativePayout.Cmp(w.CumulativePayout) != 0 || e.CallerPayout.Cmp(w.CallerPayout) != 0 {
+					t.Fatalf("got payouts %s, %s and %s, want %s, %s and %s", e.TotalPayout, e.CumulativePayout, e.CallerPayout, w.TotalPayout, w.CumulativePayout, w.CallerPayout)
+				}
+			}
+		})
+	}
+}
@@ -2,11 +2,15 @@ package swap
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/http"
 	"net/url"
 	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // compile check whether GethClient implements Swap interface
@@ -71,6 +75,63 @@ type ethRequestParams struct {
 	GasPrice string
 }
 
+// Account returns Geth node's account deposits are made from
+func (g *GethClient) Account() string {
+	return g.ethAccount
+}
+
+// ETHBalance returns ETH balance of the address in wei
+func (g *GethClient) ETHBalance(ctx context.Context, address string) (balance *big.Int, err error) {
+	var result string
+	if err := g.call(ctx, "eth_getBalance", &result, address, "latest"); err != nil {
+		return nil, err
+	}
+
+	balance, ok := new(big.Int).SetString(strings.TrimPrefix(result, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid balance %s", result)
+	}
+
+	return balance, nil
+}
+
+// BZZBalance returns BZZ token balance of the address in token's base units
+func (g *GethClient) BZZBalance(ctx context.Context, address string) (balance *big.Int, err error) {
+	var result string
+	if err := g.call(ctx, "eth_call", &result, map[string]string{
+		"to":   g.bzzTokenAddress,
+		"data": balanceOfBzz + fmt.Sprintf("%064s", strings.TrimPrefix(address, "0x")),
+	}, "latest"); err != nil {
+		return nil, err
+	}
+
+	balance, ok := new(big.Int).SetString(strings.TrimPrefix(result, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid balance %s", result)
+	}
+
+	return balance, nil
+}
+
+// TransactionReceipt returns receipt of the mined transaction, ethereum.NotFound
+// is returned if the transaction is not mined yet
+func (g *GethClient) TransactionReceipt(ctx context.Context, tx string) (receipt *types.Receipt, err error) {
+	var result json.RawMessage
+	if err := g.call(ctx, "eth_getTransactionReceipt", &result, tx); err != nil {
+		return nil, err
+	}
+	if string(result) == "null" {
+		return nil, ethereum.NotFound
+	}
+
+	receipt = new(types.Receipt)
+	if err := json.Unmarshal(result, receipt); err != nil {
+		return nil, fmt.Errorf("unmarshal receipt: %w", err)
+	}
+
+	return
+}
+
 // SendETH makes ETH deposit
 func (g *GethClient) SendETH(ctx context.Context, to string, amount float64) (tx string, err error) {
 	ethAccounts, err := g.ethAccounts(ctx)
@@ -204,6 +265,39 @@ func (g *GethClient) ethAccounts(ctx context.Context) (a []string, err error) {
 	return resp.Result, nil
 }
 
+// call makes JSON-RPC request with given params and decodes its result
+func (g *GethClient) call(ctx context.Context, method string, result interface{}, params ...interface{}) (err error) {
+	req := struct {
+		ID      string        `json:"id"`
+		JsonRPC string        `json:"jsonrpc"`
+		Method  string        `json:"method"`
+		Params  []interface{} `json:"params"`
+	}{
+		ID:      "0",
+		JsonRPC: "2.0",
+		Method:  method,
+		Params:  params,
+	}
+
+	resp := new(struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	})
+
+	if err := requestJSON(ctx, g.httpClient, http.MethodPost, "/", req, &resp); err != nil {
+		return err
+	}
+
+	if resp.Error != nil {
+		return fmt.Errorf("%s: %s (code %d)", method, resp.Error.Message, resp.Error.Code)
+	}
+
+	return json.Unmarshal(resp.Result, result)
+}
+
 // contains checks if list contains string
 func contains(list []string, find string) bool {
 	for _, v := range list {
@@ -3,6 +3,9 @@ package swap
 import (
 	"context"
 	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // ErrNotSet represents error when Swap client is not set
@@ -13,6 +16,21 @@ var _ Client = (*NotSet)(nil)
 
 type NotSet struct{}
 
+// ETHBalance returns ETH balance of the address in wei
+func (n *NotSet) ETHBalance(ctx context.Context, address string) (balance *big.Int, err error) {
+	return nil, ErrNotSet
+}
+
+// BZZBalance returns BZZ token balance of the address in token's base units
+func (n *NotSet) BZZBalance(ctx context.Context, address string) (balance *big.Int, err error) {
+	return nil, ErrNotSet
+}
+
+// TransactionReceipt returns receipt of the mined transaction
+func (n *NotSet) TransactionReceipt(ctx context.Context, tx string) (receipt *types.Receipt, err error) {
+	return nil, ErrNotSet
+}
+
 // SendETH makes ETH deposit
 func (n *NotSet) SendETH(ctx context.Context, to string, amount float64) (tx string, err error) {
 	return "", ErrNotSet
@@ -0,0 +1,375 @@
+package swap
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// compile check whether SignerClient implements Swap and Sweeper interfaces
+var (
+	_ Client  = (*SignerClient)(nil)
+	_ Sweeper = (*SignerClient)(nil)
+)
+
+// SignerClient signs transactions locally and sends them with
+// eth_sendRawTransaction, so it works with any standard JSON-RPC node
+type SignerClient struct {
+	backend         *ethclient.Client
+	bzzTokenAddress common.Address
+	chainID         *big.Int
+	confirmations   uint64
+	gasPrice        *big.Int
+	key             *ecdsa.PrivateKey
+	pollInterval    time.Duration
+
+	mu    sync.Mutex // serializes nonce assignment and sending
+	nonce *uint64    // next nonce, fetched from the node if not set
+}
+
+// SignerClientOptions holds optional parameters for the SignerClient
+type SignerClientOptions struct {
+	BzzTokenAddress string
+	// Confirmations is the number of blocks, including the one with the transaction, to wait for
+	Confirmations uint64
+	// GasPrice is used for all transactions, if not set price suggested by the node is used
+	GasPrice *big.Int
+	// PrivateKey is hex encoded private key, it is used instead of the keystore if set
+	PrivateKey string
+	// Keystore is path to the keystore file, decrypted with the KeystorePassword
+	Keystore         string
+	KeystorePassword string
+	// PollInterval is the interval in which receipts and new blocks are checked
+	PollInterval time.Duration
+}
+
+// NewSignerClient connects to the JSON-RPC endpoint and constructs a new SignerClient
+func NewSignerClient(ctx context.Context, endpoint string, o *SignerClientOptions) (c *SignerClient, err error) {
+	if o == nil {
+		o = new(SignerClientOptions)
+	}
+
+	if len(o.BzzTokenAddress) == 0 {
+		o.BzzTokenAddress = BzzTokenAddress
+	}
+
+	if o.Confirmations == 0 {
+		o.Confirmations = 1
+	}
+
+	if o.PollInterval == 0 {
+		o.PollInterval = time.Second
+	}
+
+	key, err := signerKey(o)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := ethclient.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", endpoint, err)
+	}
+
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		backend.Close()
+		return nil, fmt.Errorf("chain ID: %w", err)
+	}
+
+	return &SignerClient{
+		backend:         backend,
+		bzzTokenAddress: common.HexToAddress(o.BzzTokenAddress),
+		chainID:         chainID,
+		confirmations:   o.Confirmations,
+		gasPrice:        o.GasPrice,
+		key:             key,
+		pollInterval:    o.PollInterval,
+	}, nil
+}
+
+// signerKey returns private key from the options
+func signerKey(o *SignerClientOptions) (key *ecdsa.PrivateKey, err error) {
+	if len(o.PrivateKey) > 0 {
+		key, err = crypto.HexToECDSA(strings.TrimPrefix(o.PrivateKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("parse private key: %w", err)
+		}
+		return key, nil
+	}
+
+	if len(o.Keystore) == 0 {
+		return nil, errors.New("private key or keystore not set")
+	}
+
+	data, err := ioutil.ReadFile(o.Keystore)
+	if err != nil {
+		return nil, fmt.Errorf("read keystore: %w", err)
+	}
+
+	k, err := keystore.DecryptKey(data, o.KeystorePassword)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore: %w", err)
+	}
+
+	return k.PrivateKey, nil
+}
+
+// Address returns address of the account transactions are sent from
+func (c *SignerClient) Address() string {
+	return crypto.PubkeyToAddress(c.key.PublicKey).Hex()
+}
+
+// ETHBalance returns ETH balance of the address in wei
+func (c *SignerClient) ETHBalance(ctx context.Context, address string) (balance *big.Int, err error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid address %s", address)
+	}
+
+	return c.backend.BalanceAt(ctx, common.HexToAddress(address), nil)
+}
+
+// BZZBalance returns BZZ token balance of the address in token's base units
+func (c *SignerClient) BZZBalance(ctx context.Context, address string) (balance *big.Int, err error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid address %s", address)
+	}
+
+	result, err := c.backend.CallContract(ctx, ethereum.CallMsg{
+		To:   &c.bzzTokenAddress,
+		Data: common.FromHex(balanceOfBzz + fmt.Sprintf("%064s", strings.TrimPrefix(address, "0x"))),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(result), nil
+}
+
+// TransactionReceipt returns receipt of the mined transaction, ethereum.NotFound
+// is returned if the transaction is not mined yet
+func (c *SignerClient) TransactionReceipt(ctx context.Context, tx string) (receipt *types.Receipt, err error) {
+	return c.backend.TransactionReceipt(ctx, common.HexToHash(tx))
+}
+
+// Close closes connection to the JSON-RPC endpoint
+func (c *SignerClient) Close() {
+	c.backend.Close()
+}
+
+// SendETH makes ETH deposit
+func (c *SignerClient) SendETH(ctx context.Context, to string, amount float64) (tx string, err error) {
+	if !common.IsHexAddress(to) {
+		return "", fmt.Errorf("invalid address %s", to)
+	}
+
+	return c.transact(ctx, common.HexToAddress(to), float64ToBigInt(amount, 1000000000000000000), nil) // 18 zeroes
+}
+
+// SendBZZ makes BZZ token deposit
+func (c *SignerClient) SendBZZ(ctx context.Context, to string, amount float64) (tx string, err error) {
+	if !common.IsHexAddress(to) {
+		return "", fmt.Errorf("invalid address %s", to)
+	}
+
+	data := common.FromHex(mintBzz + fmt.Sprintf("%064s", strings.TrimPrefix(to, "0x")) + fmt.Sprintf("%064x", float64ToBigInt(amount, 10000000000000000))) // 16 zeroes
+	return c.transact(ctx, c.bzzTokenAddress, nil, data)
+}
+
+// SendGBZZ makes gBZZ token deposit
+func (c *SignerClient) SendGBZZ(ctx context.Context, to string, amount float64) (tx string, err error) {
+	if !common.IsHexAddress(to) {
+		return "", fmt.Errorf("invalid address %s", to)
+	}
+
+	data := common.FromHex(transferBzz + fmt.Sprintf("%064s", strings.TrimPrefix(to, "0x")) + fmt.Sprintf("%064x", float64ToBigInt(amount, 10000000000000000))) // 16 zeroes
+	return c.transact(ctx, c.bzzTokenAddress, nil, data)
+}
+
+// SweepBZZ transfers all account's BZZ tokens to the address
+func (c *SignerClient) SweepBZZ(ctx context.Context, to string) (tx string, amount *big.Int, err error) {
+	if !common.IsHexAddress(to) {
+		return "", nil, fmt.Errorf("invalid address %s", to)
+	}
+
+	amount, err = c.BZZBalance(ctx, c.Address())
+	if err != nil {
+		return "", nil, fmt.Errorf("bzz balance: %w", err)
+	}
+	if amount.Sign() == 0 {
+		return "", amount, nil
+	}
+
+	data := common.FromHex(transferBzz + fmt.Sprintf("%064s", strings.TrimPrefix(to, "0x")) + fmt.Sprintf("%064x", amount))
+	if tx, err = c.transact(ctx, c.bzzTokenAddress, nil, data); err != nil {
+		return "", nil, err
+	}
+
+	return
+}
+
+// SweepETH transfers all account's ETH, except the transaction fee, to the address
+func (c *SignerClient) SweepETH(ctx context.Context, to string) (tx string, amount *big.Int, err error) {
+	if !common.IsHexAddress(to) {
+		return "", nil, fmt.Errorf("invalid address %s", to)
+	}
+
+	balance, err := c.ETHBalance(ctx, c.Address())
+	if err != nil {
+		return "", nil, fmt.Errorf("eth balance: %w", err)
+	}
+
+	gasPrice, err := c.suggestGasPrice(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	fee := new(big.Int).Mul(gasPrice, big.NewInt(EthGasLimit))
+	if balance.Cmp(fee) <= 0 {
+		return "", new(big.Int), nil
+	}
+	amount = balance.Sub(balance, fee)
+
+	if tx, err = c.transactWithGas(ctx, common.HexToAddress(to), amount, EthGasLimit, gasPrice, nil); err != nil {
+		return "", nil, err
+	}
+
+	return
+}
+
+// transact sends transaction and waits for its receipt
+func (c *SignerClient) transact(ctx context.Context, to common.Address, value *big.Int, data []byte) (tx string, err error) {
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	from := crypto.PubkeyToAddress(c.key.PublicKey)
+	gas, err := c.backend.EstimateGas(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    &to,
+		Value: value,
+		Data:  data,
+	})
+	if err != nil {
+		return "", fmt.Errorf("estimate gas: %w", err)
+	}
+
+	gasPrice, err := c.suggestGasPrice(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return c.transactWithGas(ctx, to, value, gas, gasPrice, data)
+}
+
+// suggestGasPrice returns configured gas price or the price suggested by the node
+func (c *SignerClient) suggestGasPrice(ctx context.Context) (gasPrice *big.Int, err error) {
+	if c.gasPrice != nil {
+		return c.gasPrice, nil
+	}
+
+	if gasPrice, err = c.backend.SuggestGasPrice(ctx); err != nil {
+		return nil, fmt.Errorf("suggest gas price: %w", err)
+	}
+
+	return
+}
+
+// transactWithGas sends transaction with given gas limit and price and waits for its receipt
+func (c *SignerClient) transactWithGas(ctx context.Context, to common.Address, value *big.Int, gas uint64, gasPrice *big.Int, data []byte) (tx string, err error) {
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	t, err := c.send(ctx, func(nonce uint64) *types.Transaction {
+		return types.NewTransaction(nonce, to, value, gas, gasPrice, data)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.waitReceipt(ctx, t.Hash()); err != nil {
+		return "", err
+	}
+
+	return t.Hash().Hex(), nil
+}
+
+// send assigns nonce to the transaction, signs it and sends it to the node
+func (c *SignerClient) send(ctx context.Context, newTx func(nonce uint64) *types.Transaction) (tx *types.Transaction, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.nonce == nil {
+		nonce, err := c.backend.PendingNonceAt(ctx, crypto.PubkeyToAddress(c.key.PublicKey))
+		if err != nil {
+			return nil, fmt.Errorf("pending nonce: %w", err)
+		}
+		c.nonce = &nonce
+	}
+
+	tx, err = types.SignTx(newTx(*c.nonce), types.NewEIP155Signer(c.chainID), c.key)
+	if err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+
+	if err := c.backend.SendTransaction(ctx, tx); err != nil {
+		// nonce is fetched from the node again as the node's state is unknown
+		c.nonce = nil
+		return nil, fmt.Errorf("send transaction: %w", err)
+	}
+	*c.nonce++
+
+	return
+}
+
+// waitReceipt waits until transaction is mined and confirmed with the
+// required number of blocks and checks whether it succeeded
+func (c *SignerClient) waitReceipt(ctx context.Context, hash common.Hash) (err error) {
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	var r *types.Receipt
+	for {
+		if r == nil {
+			r, err = c.backend.TransactionReceipt(ctx, hash)
+			if err != nil && !errors.Is(err, ethereum.NotFound) {
+				return fmt.Errorf("transaction %s receipt: %w", hash.Hex(), err)
+			}
+		}
+
+		if r != nil {
+			h, err := c.backend.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return fmt.Errorf("latest block: %w", err)
+			}
+
+			confirmations := new(big.Int).Sub(h.Number, r.BlockNumber)
+			if confirmations.Cmp(new(big.Int).SetUint64(c.confirmations-1)) >= 0 {
+				if r.Status != types.ReceiptStatusSuccessful {
+					return fmt.Errorf("transaction %s failed", hash.Hex())
+				}
+				return nil
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for transaction %s: %w", hash.Hex(), ctx.Err())
+		}
+	}
+}
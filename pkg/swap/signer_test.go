@@ -0,0 +1,168 @@
+package swap
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const signerRecipient = "0x62cab2b3b55f341f10348720ca18063cdb779ad5"
+
+func TestSignerClientConcurrentNonces(t *testing.T) {
+	ctx := context.Background()
+	sim := newSimulatedClient(t, "seed")
+	c := newSignerClient(t, sim, 1)
+
+	const sends = 5
+	hashes := make([]string, sends)
+	errs := make([]error, sends)
+	var wg sync.WaitGroup
+	for i := 0; i < sends; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			hashes[i], errs[i] = c.SendETH(ctx, signerRecipient, 0.001)
+		}(i)
+	}
+	wg.Wait()
+
+	nonces := make([]uint64, 0, sends)
+	for i, h := range hashes {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		tx, _, err := sim.backend.TransactionByHash(ctx, common.HexToHash(h))
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonces = append(nonces, tx.Nonce())
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	for i := 1; i < len(nonces); i++ {
+		if nonces[i] != nonces[0]+uint64(i) {
+			t.Fatalf("got nonces %v, want sequential", nonces)
+		}
+	}
+
+	if *c.nonce != nonces[len(nonces)-1]+1 {
+		t.Fatalf("got next nonce %d, want %d", *c.nonce, nonces[len(nonces)-1]+1)
+	}
+}
+
+func TestSignerClientNonceAfterSendError(t *testing.T) {
+	ctx := context.Background()
+	sim := newSimulatedClient(t, "seed")
+	c := newSignerClient(t, sim, 1)
+
+	if _, err := c.SendETH(ctx, signerRecipient, 0.001); err != nil {
+		t.Fatal(err)
+	}
+
+	// transaction sent from the same account by other client makes the
+	// cached nonce stale
+	if _, err := sim.SendETH(ctx, signerRecipient, 0.001); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.SendETH(ctx, signerRecipient, 0.001); err == nil {
+		t.Fatal("expected error for stale nonce")
+	}
+	if c.nonce != nil {
+		t.Fatalf("got cached nonce %d after send error", *c.nonce)
+	}
+
+	tx, err := c.SendETH(ctx, signerRecipient, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := sim.backend.NonceAt(ctx, crypto.PubkeyToAddress(c.key.PublicKey), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _, err := sim.backend.TransactionByHash(ctx, common.HexToHash(tx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Nonce() != want-1 {
+		t.Fatalf("got nonce %d, want %d", got.Nonce(), want-1)
+	}
+}
+
+func TestSignerClientConfirmations(t *testing.T) {
+	const confirmations = 3
+
+	ctx := context.Background()
+	sim := newSimulatedClient(t, "seed")
+	c := newSignerClient(t, sim, confirmations)
+
+	type result struct {
+		tx  string
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		tx, err := c.SendETH(ctx, signerRecipient, 0.001)
+		done <- result{tx: tx, err: err}
+	}()
+
+	// simulated chain mines the transaction right away, so the block with it
+	// is the first confirmation and the rest are mined here
+	for i := 1; i < confirmations; i++ {
+		select {
+		case r := <-done:
+			t.Fatalf("got result after %d confirmations: %v", i, r.err)
+		case <-time.After(200 * time.Millisecond):
+		}
+		sim.mu.Lock()
+		sim.backend.Commit()
+		sim.mu.Unlock()
+	}
+
+	var r result
+	select {
+	case r = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no result after %d confirmations", confirmations)
+	}
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+
+	receipt, err := sim.TransactionReceipt(ctx, r.tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("got transaction status %d", receipt.Status)
+	}
+	head := sim.backend.Blockchain().CurrentBlock().Number()
+	if got := new(big.Int).Sub(head, receipt.BlockNumber).Int64() + 1; got != confirmations {
+		t.Fatalf("got %d confirmations, want %d", got, confirmations)
+	}
+}
+
+// newSignerClient returns SignerClient connected to the simulated chain
+// which sends transactions from the treasury account
+func newSignerClient(t *testing.T, sim *SimulatedClient, confirmations uint64) *SignerClient {
+	t.Helper()
+
+	c, err := NewSignerClient(context.Background(), "http://"+sim.Endpoint(), &SignerClientOptions{
+		Confirmations: confirmations,
+		PrivateKey:    hex.EncodeToString(crypto.FromECDSA(sim.treasury)),
+		PollInterval:  10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(c.Close)
+
+	return c
+}
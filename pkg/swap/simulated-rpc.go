@@ -0,0 +1,281 @@
+package swap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// simulatedEthAPI serves eth namespace of the simulated chain's JSON-RPC,
+// state is always served from the latest block
+type simulatedEthAPI struct {
+	c *SimulatedClient
+}
+
+// simulatedCallArgs represents arguments of eth_call and eth_estimateGas
+type simulatedCallArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      *hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     *hexutil.Bytes  `json:"data"`
+	Input    *hexutil.Bytes  `json:"input"`
+}
+
+// toCallMsg converts arguments to ethereum.CallMsg
+func (a simulatedCallArgs) toCallMsg() (m ethereum.CallMsg) {
+	m = ethereum.CallMsg{
+		From: a.From,
+		To:   a.To,
+	}
+	if a.Gas != nil {
+		m.Gas = uint64(*a.Gas)
+	}
+	if a.GasPrice != nil {
+		m.GasPrice = a.GasPrice.ToInt()
+	}
+	if a.Value != nil {
+		m.Value = a.Value.ToInt()
+	}
+	if a.Input != nil {
+		m.Data = *a.Input
+	} else if a.Data != nil {
+		m.Data = *a.Data
+	}
+
+	return
+}
+
+// ChainId returns chain ID
+func (api *simulatedEthAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(api.c.chainID)
+}
+
+// BlockNumber returns number of the latest block
+func (api *simulatedEthAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(api.c.backend.Blockchain().CurrentBlock().NumberU64())
+}
+
+// Syncing returns false as simulated chain is always synced
+func (api *simulatedEthAPI) Syncing() bool {
+	return false
+}
+
+// GasPrice returns suggested gas price
+func (api *simulatedEthAPI) GasPrice(ctx context.Context) (*hexutil.Big, error) {
+	price, err := api.c.backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(price), nil
+}
+
+// GetBalance returns account's balance
+func (api *simulatedEthAPI) GetBalance(ctx context.Context, address common.Address, _ *rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	balance, err := api.c.backend.BalanceAt(ctx, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(balance), nil
+}
+
+// GetCode returns contract's code
+func (api *simulatedEthAPI) GetCode(ctx context.Context, address common.Address, _ *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	return api.c.backend.CodeAt(ctx, address, nil)
+}
+
+// GetTransactionCount returns account's nonce
+func (api *simulatedEthAPI) GetTransactionCount(ctx context.Context, address common.Address, block *rpc.BlockNumberOrHash) (*hexutil.Uint64, error) {
+	pending := false
+	if block != nil {
+		n, ok := block.Number()
+		pending = ok && n == rpc.PendingBlockNumber
+	}
+
+	var (
+		nonce uint64
+		err   error
+	)
+	if pending {
+		nonce, err = api.c.backend.PendingNonceAt(ctx, address)
+	} else {
+		nonce, err = api.c.backend.NonceAt(ctx, address, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Uint64)(&nonce), nil
+}
+
+// Call executes call against the latest state
+func (api *simulatedEthAPI) Call(ctx context.Context, args simulatedCallArgs, _ *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	return api.c.backend.CallContract(ctx, args.toCallMsg(), nil)
+}
+
+// EstimateGas estimates gas needed for the transaction
+func (api *simulatedEthAPI) EstimateGas(ctx context.Context, args simulatedCallArgs) (hexutil.Uint64, error) {
+	gas, err := api.c.backend.EstimateGas(ctx, args.toCallMsg())
+	return hexutil.Uint64(gas), err
+}
+
+// SendRawTransaction sends signed transaction
+func (api *simulatedEthAPI) SendRawTransaction(ctx context.Context, data hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(data, tx); err != nil {
+		return common.Hash{}, fmt.Errorf("decode transaction: %w", err)
+	}
+
+	api.c.mu.Lock()
+	defer api.c.mu.Unlock()
+
+	if err := api.c.sendTransaction(ctx, tx); err != nil {
+		return common.Hash{}, err
+	}
+
+	return tx.Hash(), nil
+}
+
+// GetTransactionByHash returns transaction, block fields are set only if it is mined
+func (api *simulatedEthAPI) GetTransactionByHash(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	tx, pending, err := api.c.backend.TransactionByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	fields, err := api.marshalTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pending {
+		if l := api.c.backend.Blockchain().GetTransactionLookup(hash); l != nil {
+			fields["blockHash"] = l.BlockHash
+			fields["blockNumber"] = hexutil.Uint64(l.BlockIndex)
+			fields["transactionIndex"] = hexutil.Uint64(l.Index)
+		}
+	}
+
+	return fields, nil
+}
+
+// marshalTransaction returns transaction's JSON-RPC representation without block fields
+func (api *simulatedEthAPI) marshalTransaction(tx *types.Transaction) (fields map[string]interface{}, err error) {
+	data, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	if from, err := types.Sender(types.NewEIP155Signer(api.c.chainID), tx); err == nil {
+		fields["from"] = from
+	}
+
+	return
+}
+
+// GetTransactionReceipt returns transaction's receipt
+func (api *simulatedEthAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	return api.c.backend.TransactionReceipt(ctx, hash)
+}
+
+// GetBlockByNumber returns block, pending and latest blocks are served as latest
+func (api *simulatedEthAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
+	var n *big.Int
+	if number >= 0 {
+		n = big.NewInt(number.Int64())
+	}
+
+	block, err := api.c.backend.BlockByNumber(ctx, n)
+	if err != nil {
+		return nil, nil
+	}
+	return api.marshalBlock(block, fullTx)
+}
+
+// GetBlockByHash returns block
+func (api *simulatedEthAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (map[string]interface{}, error) {
+	block, err := api.c.backend.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, nil
+	}
+	return api.marshalBlock(block, fullTx)
+}
+
+// GetLogs returns logs matching the filter criteria
+func (api *simulatedEthAPI) GetLogs(ctx context.Context, crit filters.FilterCriteria) ([]types.Log, error) {
+	logs, err := api.c.backend.FilterLogs(ctx, ethereum.FilterQuery(crit))
+	if err != nil {
+		return nil, err
+	}
+	if logs == nil {
+		logs = []types.Log{}
+	}
+	return logs, nil
+}
+
+// marshalBlock returns block's JSON-RPC representation
+func (api *simulatedEthAPI) marshalBlock(block *types.Block, fullTx bool) (fields map[string]interface{}, err error) {
+	data, err := json.Marshal(block.Header())
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	txs := make([]interface{}, 0, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		if !fullTx {
+			txs = append(txs, tx.Hash())
+			continue
+		}
+
+		t, err := api.marshalTransaction(tx)
+		if err != nil {
+			return nil, err
+		}
+		t["blockHash"] = block.Hash()
+		t["blockNumber"] = (*hexutil.Big)(block.Number())
+		t["transactionIndex"] = hexutil.Uint64(i)
+		txs = append(txs, t)
+	}
+	fields["transactions"] = txs
+	fields["uncles"] = []common.Hash{}
+	fields["size"] = hexutil.Uint64(block.Size())
+
+	return
+}
+
+// simulatedNetAPI serves net namespace of the simulated chain's JSON-RPC
+type simulatedNetAPI struct {
+	c *SimulatedClient
+}
+
+// Version returns network ID
+func (api *simulatedNetAPI) Version() string {
+	return api.c.chainID.String()
+}
+
+// simulatedWeb3API serves web3 namespace of the simulated chain's JSON-RPC
+type simulatedWeb3API struct{}
+
+// ClientVersion returns client version
+func (api *simulatedWeb3API) ClientVersion() string {
+	return userAgent
+}
@@ -0,0 +1,475 @@
+package swap
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethersphere/sw3-bindings/v3/simpleswapfactory"
+)
+
+const (
+	simulatedGasLimit   = 8000000
+	simulatedBzzDecimal = 16
+	// simulatedTreasurySalt is hashed with the treasury seed to derive the treasury key
+	simulatedTreasurySalt = "beekeeper-simulated-treasury"
+)
+
+var (
+	// simulatedETHSupply is treasury's ETH balance, 1e9 ETH
+	simulatedETHSupply = new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil)
+	// simulatedBZZSupply is treasury's BZZ balance, 1e9 BZZ
+	simulatedBZZSupply = new(big.Int).Exp(big.NewInt(10), big.NewInt(9+simulatedBzzDecimal), nil)
+)
+
+// compile check whether SimulatedClient implements Swap interface
+var _ Client = (*SimulatedClient)(nil)
+
+// SimulatedClient runs in-process simulated Ethereum chain with Swap contracts
+// deployed and exposes it through JSON-RPC endpoint Bee nodes can use as
+// swap-endpoint. All deposits are made from the treasury account that owns
+// whole ETH and BZZ supply.
+type SimulatedClient struct {
+	backend   *backends.SimulatedBackend
+	blockTime time.Duration
+	chainID   *big.Int
+	contracts SimulatedContracts
+	erc20     abi.ABI
+	listener  net.Listener
+	server    *http.Server
+	treasury  *ecdsa.PrivateKey
+
+	mu   sync.Mutex // serializes transactions sent to the backend
+	quit chan struct{}
+}
+
+// SimulatedClientOptions holds optional parameters for the SimulatedClient
+type SimulatedClientOptions struct {
+	// ListenAddr is the address JSON-RPC endpoint listens on, both HTTP and WebSocket
+	ListenAddr string
+	// BlockTime is the interval in which blocks are mined, if zero block is mined with every transaction
+	BlockTime time.Duration
+	// PostageStampBin is postage stamp contract's creation bytecode, BZZ token address is appended as constructor argument
+	PostageStampBin string
+	// PriceOracleBin is price oracle contract's creation bytecode, including ABI encoded constructor arguments
+	PriceOracleBin string
+	// TreasurySeed is the seed treasury key is derived from, as contracts are
+	// deployed from the treasury, the same seed yields the same contract addresses
+	TreasurySeed string
+}
+
+// SimulatedContracts holds addresses of contracts deployed to the simulated chain
+type SimulatedContracts struct {
+	BzzToken     string
+	PostageStamp string
+	PriceOracle  string
+	SwapFactory  string
+}
+
+// NewSimulatedClient starts simulated chain, deploys contracts and starts JSON-RPC endpoint
+func NewSimulatedClient(o *SimulatedClientOptions) (c *SimulatedClient, err error) {
+	if o == nil {
+		o = new(SimulatedClientOptions)
+	}
+
+	if len(o.ListenAddr) == 0 {
+		o.ListenAddr = ":8545"
+	}
+
+	treasury, err := simulatedTreasury(o.TreasurySeed)
+	if err != nil {
+		return nil, fmt.Errorf("derive treasury key: %w", err)
+	}
+
+	erc20, err := abi.JSON(strings.NewReader(simpleswapfactory.ERC20ABI))
+	if err != nil {
+		return nil, fmt.Errorf("parse ERC20 ABI: %w", err)
+	}
+
+	alloc, err := simulatedGenesis(treasury)
+	if err != nil {
+		return nil, fmt.Errorf("simulated genesis: %w", err)
+	}
+
+	backend := backends.NewSimulatedBackend(alloc, simulatedGasLimit)
+	c = &SimulatedClient{
+		backend:   backend,
+		blockTime: o.BlockTime,
+		chainID:   backend.Blockchain().Config().ChainID,
+		contracts: SimulatedContracts{BzzToken: BzzTokenAddress},
+		erc20:     erc20,
+		treasury:  treasury,
+		quit:      make(chan struct{}),
+	}
+
+	if err := c.deployContracts(o); err != nil {
+		backend.Close()
+		return nil, fmt.Errorf("deploy contracts: %w", err)
+	}
+
+	if err := c.serve(o.ListenAddr); err != nil {
+		backend.Close()
+		return nil, fmt.Errorf("serve JSON-RPC on %s: %w", o.ListenAddr, err)
+	}
+
+	if c.blockTime > 0 {
+		go c.mine()
+	}
+
+	return c, nil
+}
+
+// simulatedTreasury derives treasury key from the seed
+func simulatedTreasury(seed string) (key *ecdsa.PrivateKey, err error) {
+	return crypto.ToECDSA(crypto.Keccak256([]byte(simulatedTreasurySalt), []byte(seed)))
+}
+
+// simulatedGenesis returns genesis allocation with treasury account funded
+// with ETH and BZZ token placed at the default BZZ token address, token's
+// whole supply is assigned to the treasury
+func simulatedGenesis(key *ecdsa.PrivateKey) (alloc core.GenesisAlloc, err error) {
+	// ERC20 token is not mintable, so its runtime code and name and symbol
+	// storage are taken from the deployment to the temporary backend and
+	// balances are set directly in the genesis
+	treasury := crypto.PubkeyToAddress(key.PublicKey)
+	tmp := backends.NewSimulatedBackend(core.GenesisAlloc{
+		treasury: {Balance: simulatedETHSupply},
+	}, simulatedGasLimit)
+	defer tmp.Close()
+
+	address, _, _, err := simpleswapfactory.DeployERC20(bind.NewKeyedTransactor(key), tmp, "Swarm Token", "BZZ")
+	if err != nil {
+		return nil, fmt.Errorf("deploy ERC20: %w", err)
+	}
+	tmp.Commit()
+
+	ctx := context.Background()
+	code, err := tmp.CodeAt(ctx, address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("ERC20 code: %w", err)
+	}
+
+	// OpenZeppelin ERC20 storage layout: balances, allowances, total supply, name, symbol, decimals
+	storage := map[common.Hash]common.Hash{
+		crypto.Keccak256Hash(common.LeftPadBytes(treasury.Bytes(), 32), common.LeftPadBytes(nil, 32)): common.BigToHash(simulatedBZZSupply),
+		common.BigToHash(big.NewInt(2)): common.BigToHash(simulatedBZZSupply),
+		common.BigToHash(big.NewInt(5)): common.BigToHash(big.NewInt(simulatedBzzDecimal)),
+	}
+	for _, slot := range []int64{3, 4} {
+		v, err := tmp.StorageAt(ctx, address, common.BigToHash(big.NewInt(slot)), nil)
+		if err != nil {
+			return nil, fmt.Errorf("ERC20 storage: %w", err)
+		}
+		storage[common.BigToHash(big.NewInt(slot))] = common.BytesToHash(v)
+	}
+
+	return core.GenesisAlloc{
+		treasury:                             {Balance: simulatedETHSupply},
+		common.HexToAddress(BzzTokenAddress): {Balance: new(big.Int), Code: code, Storage: storage},
+	}, nil
+}
+
+// deployContracts deploys chequebook factory and, if their bytecode is
+// provided, postage stamp and price oracle contracts
+func (c *SimulatedClient) deployContracts(o *SimulatedClientOptions) (err error) {
+	ctx := context.Background()
+	token := common.HexToAddress(c.contracts.BzzToken)
+
+	factory, _, _, err := simpleswapfactory.DeploySimpleSwapFactory(bind.NewKeyedTransactor(c.treasury), c.backend, token)
+	if err != nil {
+		return fmt.Errorf("swap factory: %w", err)
+	}
+	c.backend.Commit()
+	c.contracts.SwapFactory = factory.Hex()
+
+	if len(o.PostageStampBin) > 0 {
+		code := append(common.FromHex(strings.TrimSpace(o.PostageStampBin)), common.LeftPadBytes(token.Bytes(), 32)...)
+		address, err := c.deploy(ctx, code)
+		if err != nil {
+			return fmt.Errorf("postage stamp: %w", err)
+		}
+		c.contracts.PostageStamp = address.Hex()
+	}
+
+	if len(o.PriceOracleBin) > 0 {
+		address, err := c.deploy(ctx, common.FromHex(strings.TrimSpace(o.PriceOracleBin)))
+		if err != nil {
+			return fmt.Errorf("price oracle: %w", err)
+		}
+		c.contracts.PriceOracle = address.Hex()
+	}
+
+	return
+}
+
+// deploy deploys contract from treasury account and returns its address
+func (c *SimulatedClient) deploy(ctx context.Context, code []byte) (address common.Address, err error) {
+	from := crypto.PubkeyToAddress(c.treasury.PublicKey)
+	tx, err := c.send(ctx, nil, nil, simulatedGasLimit, code)
+	if err != nil {
+		return common.Address{}, err
+	}
+	// without block time the transaction is already mined by sendTransaction,
+	// contracts are deployed before mining every block time starts
+	if c.blockTime > 0 {
+		c.backend.Commit()
+	}
+
+	r, err := c.backend.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return common.Address{}, fmt.Errorf("receipt: %w", err)
+	}
+	if r == nil || r.Status != types.ReceiptStatusSuccessful {
+		return common.Address{}, fmt.Errorf("transaction %s from %s reverted", tx.Hash().Hex(), from.Hex())
+	}
+
+	return r.ContractAddress, nil
+}
+
+// serve starts JSON-RPC server
+func (c *SimulatedClient) serve(addr string) (err error) {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", &simulatedEthAPI{c: c}); err != nil {
+		return err
+	}
+	if err := server.RegisterName("net", &simulatedNetAPI{c: c}); err != nil {
+		return err
+	}
+	if err := server.RegisterName("web3", &simulatedWeb3API{}); err != nil {
+		return err
+	}
+	ws := server.WebsocketHandler([]string{"*"})
+
+	if c.listener, err = net.Listen("tcp", addr); err != nil {
+		return err
+	}
+
+	c.server = &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+				ws.ServeHTTP(w, r)
+				return
+			}
+			server.ServeHTTP(w, r)
+		}),
+	}
+
+	go func() {
+		if err := c.server.Serve(c.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("simulated chain JSON-RPC server: %v\n", err)
+		}
+	}()
+
+	return
+}
+
+// mine mines new block every block time
+func (c *SimulatedClient) mine() {
+	ticker := time.NewTicker(c.blockTime)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.mu.Lock()
+			c.backend.Commit()
+			c.mu.Unlock()
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// Close stops JSON-RPC server and simulated chain
+func (c *SimulatedClient) Close() (err error) {
+	close(c.quit)
+
+	if err := c.server.Close(); err != nil {
+		return fmt.Errorf("close JSON-RPC server: %w", err)
+	}
+
+	return c.backend.Close()
+}
+
+// Contracts returns addresses of contracts deployed to the simulated chain
+func (c *SimulatedClient) Contracts() SimulatedContracts {
+	return c.contracts
+}
+
+// Endpoint returns address of JSON-RPC endpoint
+func (c *SimulatedClient) Endpoint() string {
+	return c.listener.Addr().String()
+}
+
+// Treasury returns address of the account deposits are made from
+func (c *SimulatedClient) Treasury() string {
+	return crypto.PubkeyToAddress(c.treasury.PublicKey).Hex()
+}
+
+// ETHBalance returns ETH balance of the address in wei
+func (c *SimulatedClient) ETHBalance(ctx context.Context, address string) (balance *big.Int, err error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid address %s", address)
+	}
+
+	return c.backend.BalanceAt(ctx, common.HexToAddress(address), nil)
+}
+
+// BZZBalance returns BZZ token balance of the address in token's base units
+func (c *SimulatedClient) BZZBalance(ctx context.Context, address string) (balance *big.Int, err error) {
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid address %s", address)
+	}
+
+	data, err := c.erc20.Pack("balanceOf", common.HexToAddress(address))
+	if err != nil {
+		return nil, fmt.Errorf("pack balanceOf: %w", err)
+	}
+
+	token := common.HexToAddress(c.contracts.BzzToken)
+	result, err := c.backend.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(result), nil
+}
+
+// TransactionReceipt returns receipt of the mined transaction, ethereum.NotFound
+// is returned if the transaction is not mined yet
+func (c *SimulatedClient) TransactionReceipt(ctx context.Context, tx string) (receipt *types.Receipt, err error) {
+	if receipt, err = c.backend.TransactionReceipt(ctx, common.HexToHash(tx)); err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return
+}
+
+// SendETH makes ETH deposit
+func (c *SimulatedClient) SendETH(ctx context.Context, to string, amount float64) (tx string, err error) {
+	if !common.IsHexAddress(to) {
+		return "", fmt.Errorf("invalid address %s", to)
+	}
+	address := common.HexToAddress(to)
+
+	t, err := c.send(ctx, &address, float64ToBigInt(amount, 1000000000000000000), EthGasLimit, nil) // 18 zeroes
+	if err != nil {
+		return "", err
+	}
+
+	return t.Hash().Hex(), nil
+}
+
+// SendBZZ makes BZZ token deposit, tokens are transferred from the treasury as simulated token is not mintable
+func (c *SimulatedClient) SendBZZ(ctx context.Context, to string, amount float64) (tx string, err error) {
+	return c.transferBZZ(ctx, to, amount)
+}
+
+// SendGBZZ makes gBZZ token deposit
+func (c *SimulatedClient) SendGBZZ(ctx context.Context, to string, amount float64) (tx string, err error) {
+	return c.transferBZZ(ctx, to, amount)
+}
+
+// transferBZZ transfers BZZ tokens from the treasury
+func (c *SimulatedClient) transferBZZ(ctx context.Context, to string, amount float64) (tx string, err error) {
+	if !common.IsHexAddress(to) {
+		return "", fmt.Errorf("invalid address %s", to)
+	}
+
+	data, err := c.erc20.Pack("transfer", common.HexToAddress(to), float64ToBigInt(amount, 10000000000000000)) // 16 zeroes
+	if err != nil {
+		return "", fmt.Errorf("pack transfer: %w", err)
+	}
+
+	token := common.HexToAddress(c.contracts.BzzToken)
+	t, err := c.send(ctx, &token, nil, BzzGasLimit, data)
+	if err != nil {
+		return "", err
+	}
+
+	return t.Hash().Hex(), nil
+}
+
+// send signs transaction with treasury key and sends it to the backend
+func (c *SimulatedClient) send(ctx context.Context, to *common.Address, value *big.Int, gas uint64, data []byte) (tx *types.Transaction, err error) {
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	nonce, err := c.backend.PendingNonceAt(ctx, crypto.PubkeyToAddress(c.treasury.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("nonce: %w", err)
+	}
+
+	if to == nil {
+		tx = types.NewContractCreation(nonce, value, gas, big.NewInt(GasPrice), data)
+	} else {
+		tx = types.NewTransaction(nonce, *to, value, gas, big.NewInt(GasPrice), data)
+	}
+
+	if tx, err = types.SignTx(tx, types.NewEIP155Signer(c.chainID), c.treasury); err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+
+	if err := c.sendTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// sendTransaction sends signed transaction to the backend, it must be called
+// with the lock held
+func (c *SimulatedClient) sendTransaction(ctx context.Context, tx *types.Transaction) (err error) {
+	sender, err := types.Sender(types.NewEIP155Signer(c.chainID), tx)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+
+	nonce, err := c.backend.PendingNonceAt(ctx, sender)
+	if err != nil {
+		return fmt.Errorf("nonce: %w", err)
+	}
+	if tx.Nonce() != nonce {
+		return fmt.Errorf("invalid nonce %d, expected %d", tx.Nonce(), nonce)
+	}
+
+	// simulated backend panics on transactions that can not be applied
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid transaction: %v", r)
+		}
+	}()
+
+	if err := c.backend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+
+	if c.blockTime == 0 {
+		c.backend.Commit()
+	}
+
+	return
+}
@@ -0,0 +1,144 @@
+package swap
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSimulatedGenesis(t *testing.T) {
+	key, err := simulatedTreasury("seed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	treasury := crypto.PubkeyToAddress(key.PublicKey)
+
+	alloc, err := simulatedGenesis(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := alloc[treasury].Balance; got.Cmp(simulatedETHSupply) != 0 {
+		t.Fatalf("got treasury ETH balance %s, want %s", got, simulatedETHSupply)
+	}
+
+	token, ok := alloc[common.HexToAddress(BzzTokenAddress)]
+	if !ok {
+		t.Fatal("BZZ token not in genesis")
+	}
+	if len(token.Code) == 0 {
+		t.Fatal("BZZ token without code")
+	}
+	// balances mapping is in slot 0, so balance is at keccak(address . 0)
+	slot := crypto.Keccak256Hash(common.LeftPadBytes(treasury.Bytes(), 32), common.LeftPadBytes(nil, 32))
+	if got := token.Storage[slot].Big(); got.Cmp(simulatedBZZSupply) != 0 {
+		t.Fatalf("got treasury BZZ balance slot %s, want %s", got, simulatedBZZSupply)
+	}
+}
+
+func TestSimulatedClient(t *testing.T) {
+	ctx := context.Background()
+
+	c := newSimulatedClient(t, "seed")
+
+	// balance is read through the token's balanceOf, so it verifies that
+	// genesis storage matches the token's storage layout
+	balance, err := c.BZZBalance(ctx, c.Treasury())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Cmp(simulatedBZZSupply) != 0 {
+		t.Fatalf("got treasury BZZ balance %s, want %s", balance, simulatedBZZSupply)
+	}
+
+	to := "0x62cab2b3b55f341f10348720ca18063cdb779ad5"
+	tx, err := c.SendBZZ(ctx, to, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipt, err := c.TransactionReceipt(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != 1 {
+		t.Fatalf("got transfer status %d", receipt.Status)
+	}
+
+	amount := big.NewInt(10000000000000000)
+	if balance, err = c.BZZBalance(ctx, to); err != nil {
+		t.Fatal(err)
+	}
+	if balance.Cmp(amount) != 0 {
+		t.Fatalf("got recipient BZZ balance %s, want %s", balance, amount)
+	}
+	if balance, err = c.BZZBalance(ctx, c.Treasury()); err != nil {
+		t.Fatal(err)
+	}
+	if want := new(big.Int).Sub(simulatedBZZSupply, amount); balance.Cmp(want) != 0 {
+		t.Fatalf("got treasury BZZ balance %s, want %s", balance, want)
+	}
+}
+
+func TestSimulatedClientDeterministic(t *testing.T) {
+	c1 := newSimulatedClient(t, "seed")
+	c2 := newSimulatedClient(t, "seed")
+	other := newSimulatedClient(t, "other seed")
+
+	if c1.Treasury() != c2.Treasury() {
+		t.Fatalf("got treasuries %s and %s for the same seed", c1.Treasury(), c2.Treasury())
+	}
+	if c1.Contracts() != c2.Contracts() {
+		t.Fatalf("got contracts %+v and %+v for the same seed", c1.Contracts(), c2.Contracts())
+	}
+
+	if c1.Treasury() == other.Treasury() {
+		t.Fatal("got the same treasury for different seeds")
+	}
+	if c1.Contracts().SwapFactory == other.Contracts().SwapFactory {
+		t.Fatal("got the same swap factory for different seeds")
+	}
+}
+
+func TestSimulatedClientDeploy(t *testing.T) {
+	ctx := context.Background()
+	c := newSimulatedClient(t, "seed")
+
+	before := c.backend.Blockchain().CurrentBlock().NumberU64()
+	// creation code returning empty runtime code
+	address, err := c.deploy(ctx, common.FromHex("60006000f3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address == (common.Address{}) {
+		t.Fatal("got zero contract address")
+	}
+
+	// without block time the transaction is mined in a single block
+	if after := c.backend.Blockchain().CurrentBlock().NumberU64(); after != before+1 {
+		t.Fatalf("got %d blocks mined, want 1", after-before)
+	}
+
+	// creation code that reverts
+	if _, err := c.deploy(ctx, common.FromHex("60006000fd")); err == nil {
+		t.Fatal("expected error for reverted deployment")
+	}
+}
+
+func newSimulatedClient(t *testing.T, seed string) *SimulatedClient {
+	t.Helper()
+
+	c, err := NewSimulatedClient(&SimulatedClientOptions{ListenAddr: "127.0.0.1:0", TreasurySeed: seed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return c
+}
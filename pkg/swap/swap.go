@@ -2,6 +2,9 @@ package swap
 
 import (
 	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 const (
@@ -12,11 +15,22 @@ const (
 	EthGasLimit           = 21000
 	mintBzz               = "0x40c10f19"
 	transferBzz           = "0xa9059cbb"
+	balanceOfBzz          = "0x70a08231"
 )
 
 // Client defines Client interface
 type Client interface {
+	ETHBalance(ctx context.Context, address string) (balance *big.Int, err error)
+	BZZBalance(ctx context.Context, address string) (balance *big.Int, err error)
+	TransactionReceipt(ctx context.Context, tx string) (receipt *types.Receipt, err error)
 	SendETH(ctx context.Context, to string, amount float64) (tx string, err error)
 	SendBZZ(ctx context.Context, to string, amount float64) (tx string, err error)
 	SendGBZZ(ctx context.Context, to string, amount float64) (tx string, err error)
 }
+
+// Sweeper sends whole balances of its account to another address
+type Sweeper interface {
+	SweepBZZ(ctx context.Context, to string) (tx string, amount *big.Int, err error)
+	SweepETH(ctx context.Context, to string) (tx string, amount *big.Int, err error)
+	Close()
+}